@@ -0,0 +1,78 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Permissions requested with SetAskForPermissionsConsentCard to read the device address.
+const (
+	PermissionReadAddress              = "read::alexa:device:all:address"
+	PermissionReadCountryAndPostalCode = "read::alexa:device:all:address:country_and_postal_code"
+)
+
+// ErrNoDeviceID reports that the request Context does not contain a device ID.
+var ErrNoDeviceID = errors.New("request context does not contain a device ID")
+
+// Address is the full address of a device. Fields the user has not set are empty.
+type Address struct {
+	AddressLine1     string `json:"addressLine1"`
+	AddressLine2     string `json:"addressLine2"`
+	AddressLine3     string `json:"addressLine3"`
+	City             string `json:"city"`
+	StateOrRegion    string `json:"stateOrRegion"`
+	DistrictOrCounty string `json:"districtOrCounty"`
+	CountryCode      string `json:"countryCode"`
+	PostalCode       string `json:"postalCode"`
+}
+
+// CountryAndPostalCode is the country and postal code of a device.
+type CountryAndPostalCode struct {
+	CountryCode string `json:"countryCode"`
+	PostalCode  string `json:"postalCode"`
+}
+
+// AddressClient reads the address of the device that sent a request from the
+// Device Address API. Methods return ErrPermissionNotGranted if the user has
+// not granted the matching permission.
+type AddressClient struct {
+	client   *serviceClient
+	deviceID string
+}
+
+// NewAddressClient creates an AddressClient for the device, API endpoint and
+// access token of the request Context.
+func NewAddressClient(c *Context) *AddressClient {
+	return NewServiceClientFactory(c).AddressClient()
+}
+
+// Address returns the full address of the device. It requires the
+// PermissionReadAddress permission.
+func (a *AddressClient) Address(ctx context.Context) (*Address, error) {
+	address := &Address{}
+	err := a.get(ctx, "/address", address)
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// CountryAndPostalCode returns the country and postal code of the device. It
+// requires the PermissionReadCountryAndPostalCode permission.
+func (a *AddressClient) CountryAndPostalCode(ctx context.Context) (*CountryAndPostalCode, error) {
+	address := &CountryAndPostalCode{}
+	err := a.get(ctx, "/address/countryAndPostalCode", address)
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (a *AddressClient) get(ctx context.Context, path string, v interface{}) error {
+	if a.deviceID == "" {
+		return ErrNoDeviceID
+	}
+	return a.client.do(ctx, http.MethodGet, "/v1/devices/"+url.PathEscape(a.deviceID)+"/settings"+path, nil, v)
+}
@@ -0,0 +1,100 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newAddressServer(t *testing.T, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET but was %s", r.Method)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		switch r.URL.Path {
+		case "/v1/devices/device-1/settings/address", "/v1/devices/device-1/settings/address/countryAndPostalCode":
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func newAddressContext(endpoint string) *Context {
+	c := newServiceContext(endpoint)
+	c.System.Device.DeviceID = "device-1"
+	return c
+}
+
+func TestAddressClientAddress(t *testing.T) {
+	server := newAddressServer(t, http.StatusOK, `{"addressLine1":"410 Terry Ave North","city":"Seattle","stateOrRegion":"WA","countryCode":"US","postalCode":"98109"}`)
+	defer server.Close()
+
+	address, err := NewAddressClient(newAddressContext(server.URL)).Address(context.Background())
+	if err != nil {
+		t.Fatal("Error getting address. " + err.Error())
+	}
+	expected := Address{AddressLine1: "410 Terry Ave North", City: "Seattle", StateOrRegion: "WA", CountryCode: "US", PostalCode: "98109"}
+	if *address != expected {
+		t.Errorf("Expected %+v but was %+v", expected, *address)
+	}
+}
+
+func TestAddressClientCountryAndPostalCode(t *testing.T) {
+	server := newAddressServer(t, http.StatusOK, `{"countryCode":"US","postalCode":"98109"}`)
+	defer server.Close()
+
+	address, err := NewAddressClient(newAddressContext(server.URL)).CountryAndPostalCode(context.Background())
+	if err != nil {
+		t.Fatal("Error getting country and postal code. " + err.Error())
+	}
+	if address.CountryCode != "US" || address.PostalCode != "98109" {
+		t.Errorf("Unexpected address %+v", *address)
+	}
+}
+
+func TestAddressClientPermissionNotGranted(t *testing.T) {
+	server := newAddressServer(t, http.StatusForbidden, `{"type":"FORBIDDEN","message":"The authentication token is not valid."}`)
+	defer server.Close()
+
+	_, err := NewAddressClient(newAddressContext(server.URL)).Address(context.Background())
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected ErrPermissionNotGranted but was %v", err)
+	}
+}
+
+func TestAddressClientError(t *testing.T) {
+	server := newAddressServer(t, http.StatusInternalServerError, ``)
+	defer server.Close()
+
+	_, err := NewAddressClient(newAddressContext(server.URL)).CountryAndPostalCode(context.Background())
+	if err == nil || errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected a service error but was %v", err)
+	}
+
+	_, err = NewAddressClient(newServiceContext(server.URL)).Address(context.Background())
+	if err != ErrNoDeviceID {
+		t.Errorf("Expected ErrNoDeviceID but was %v", err)
+	}
+}
+
+func TestResponseAskForPermissionsConsentCard(t *testing.T) {
+	response := &Response{}
+	response.SetAskForPermissionsConsentCard(PermissionReadAddress)
+
+	b, err := json.Marshal(response.Card)
+	if err != nil {
+		t.Fatal("Error marshalling card. " + err.Error())
+	}
+	expected := `{"type":"AskForPermissionsConsent","permissions":["read::alexa:device:all:address"]}`
+	if string(b) != expected {
+		t.Errorf("Expected %s but was %s", expected, b)
+	}
+}
@@ -2,9 +2,9 @@ package alexa
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
-	"log"
+	"log/slog"
 	"math"
 	"strconv"
 	"time"
@@ -15,18 +15,63 @@ const launchRequestName = "LaunchRequest"
 const intentRequestName = "IntentRequest"
 const sessionEndedRequestName = "SessionEndedRequest"
 
-var timestampTolerance = 150
+// defaultTimestampTolerance is the number of seconds the request timestamp may
+// differ from the current time, and the maximum Amazon allows for certified skills.
+const defaultTimestampTolerance = 150
 
 // ErrRequestEnvelopeNil reports that the request envelope was nil
 // there might be edge case which causes panic if for whatever reason this object is empty
 var ErrRequestEnvelopeNil = errors.New("request envelope was nil")
 
+// ErrRequestNil reports that the request envelope did not contain a request.
+var ErrRequestNil = errors.New("request envelope did not contain a request")
+
 // Alexa defines the primary interface to use to create an Alexa request handler.
 type Alexa struct {
 	ApplicationID       string
 	RequestHandler      RequestHandler
 	IgnoreApplicationID bool
 	IgnoreTimestamp     bool
+	// CertificationMode caps the timestamp tolerance at the 150 seconds
+	// required for skill certification.
+	CertificationMode bool
+	// Clock provides the current time for timestamp verification. Defaults to the system clock.
+	Clock Clock
+	// Logger receives log output, with access tokens redacted. Defaults to slog.Default().
+	Logger *slog.Logger
+
+	RequestInterceptors  []RequestInterceptor
+	ResponseInterceptors []ResponseInterceptor
+	ErrorInterceptors    []ErrorInterceptor
+
+	// PersistenceAdapter stores persistent attributes. Optional.
+	PersistenceAdapter PersistenceAdapter
+	// PersistenceKey selects the key persistent attributes are stored under. Defaults to UserIDKey.
+	PersistenceKey PersistenceKeyFunc
+
+	requestTypeHandlers map[string]HandlerFunc
+
+	timestampTolerance    int
+	timestampToleranceSet bool
+}
+
+type contextKey int
+
+const (
+	attributesManagerKey contextKey = iota
+	loggerKey
+)
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock used when none is set.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
 }
 
 // RequestHandler defines the interface that must be implemented to handle
@@ -39,6 +84,8 @@ type RequestHandler interface {
 }
 
 // RequestEnvelope contains the data passed from Alexa to the request handler.
+// Session is nil for requests sent outside of a session, such as AudioPlayer
+// and skill events.
 type RequestEnvelope struct {
 	Version string   `json:"version"`
 	Session *Session `json:"session"`
@@ -48,12 +95,10 @@ type RequestEnvelope struct {
 
 // Session contains the session data from the Alexa request.
 type Session struct {
-	New        bool   `json:"new"`
-	SessionID  string `json:"sessionId"`
-	Attributes struct {
-		String map[string]interface{} `json:"string"`
-	} `json:"attributes"`
-	User struct {
+	New        bool       `json:"new"`
+	SessionID  string     `json:"sessionId"`
+	Attributes Attributes `json:"attributes"`
+	User       struct {
 		UserID      string `json:"userId"`
 		AccessToken string `json:"accessToken"`
 	} `json:"user"`
@@ -82,6 +127,10 @@ type Context struct {
 				ConsentToken string `json:"consentToken"`
 			} `json:"permissions"`
 		} `json:"user"`
+		Person struct {
+			PersonID    string `json:"personId"`
+			AccessToken string `json:"accessToken"`
+		} `json:"person"`
 		APIEndpoint    string `json:"apiEndpoint"`
 		APIAccessToken string `json:"apiAccessToken"`
 	} `json:"System"`
@@ -90,6 +139,9 @@ type Context struct {
 		Token                string `json:"token"`
 		OffsetInMilliseconds int    `json:"offsetInMilliseconds"`
 	} `json:"AudioPlayer"`
+
+	// cache holds service API responses for the request being processed.
+	cache *serviceCache
 }
 
 // Request contains the data in the request within the main request.
@@ -101,6 +153,9 @@ type Request struct {
 	DialogState string `json:"dialogState"`
 	Intent      Intent `json:"intent"`
 	Name        string `json:"name"`
+
+	// raw holds the request JSON so that type specific payloads can be decoded.
+	raw json.RawMessage
 }
 
 // Intent contains the data about the Alexa Intent requested.
@@ -162,6 +217,21 @@ type Response struct {
 	Reprompt         *Reprompt     `json:"reprompt,omitempty"`
 	Directives       []interface{} `json:"directives,omitempty"`
 	ShouldSessionEnd bool          `json:"shouldEndSession"`
+
+	omitShouldSessionEnd bool
+}
+
+// MarshalJSON encodes the Response, leaving out shouldEndSession for
+// responses to requests sent outside of a session.
+func (r Response) MarshalJSON() ([]byte, error) {
+	type response Response
+	if !r.omitShouldSessionEnd {
+		return json.Marshal(response(r))
+	}
+	return json.Marshal(struct {
+		response
+		ShouldSessionEnd *bool `json:"shouldEndSession,omitempty"`
+	}{response: response(r)})
 }
 
 // OutputSpeech contains the data the defines what Alexa should say to the user.
@@ -178,6 +248,9 @@ type Card struct {
 	Content string `json:"content,omitempty"`
 	Text    string `json:"text,omitempty"`
 	Image   *Image `json:"image,omitempty"`
+
+	// Permissions lists the permissions requested by an AskForPermissionsConsent card.
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 // Image provides URL(s) to the image to display in resposne to the request.
@@ -223,80 +296,168 @@ func (alexa *Alexa) ProcessRequest(ctx context.Context, requestEnv *RequestEnvel
 	if requestEnv == nil {
 		return nil, ErrRequestEnvelopeNil
 	}
+	if requestEnv.Request == nil {
+		return nil, ErrRequestNil
+	}
+
+	logger := alexa.requestLogger(requestEnv)
+	ctx = withLogger(ctx, logger)
+	logger.Debug("Processing request.", "request", requestEnv)
 
 	if !alexa.IgnoreApplicationID {
 		err := alexa.verifyApplicationID(requestEnv)
 		if err != nil {
+			logger.Warn("Invalid Application ID.", "err", err)
 			return nil, err
 		}
 	}
 	if !alexa.IgnoreTimestamp {
 		err := alexa.verifyTimestamp(requestEnv)
 		if err != nil {
+			logger.Warn("Invalid timestamp.", "err", err)
 			return nil, err
 		}
 	} else {
-		log.Println("Ignoring timestamp verification.")
+		logger.Debug("Ignoring timestamp verification.")
+	}
+
+	if requestEnv.Context != nil && requestEnv.Context.cache == nil {
+		requestEnv.Context.cache = &serviceCache{}
 	}
 
-	request := requestEnv.Request
 	session := requestEnv.Session
-	if session.Attributes.String == nil {
+	if session != nil && session.Attributes.String == nil {
 		session.Attributes.String = make(map[string]interface{})
 	}
-	context := requestEnv.Context
 
 	responseEnv := &ResponseEnvelope{}
 	responseEnv.Version = sdkVersion
 	responseEnv.Response = &Response{}
 	responseEnv.Response.ShouldSessionEnd = true // Set default value.
+	// Requests sent outside of a session, such as AudioPlayer events, must not
+	// include shouldEndSession in the response.
+	responseEnv.Response.omitShouldSessionEnd = session == nil
 
 	response := responseEnv.Response
 
+	var attributesManager *AttributesManager
+	if alexa.PersistenceAdapter != nil {
+		attributesManager = alexa.newAttributesManager(requestEnv)
+		ctx = withAttributesManager(ctx, attributesManager)
+	}
+
+	handled, err := alexa.interceptRequest(ctx, requestEnv, response)
+	if err == nil && !handled {
+		err = alexa.dispatchRequest(ctx, requestEnv, response)
+	}
+	if err != nil {
+		err = alexa.interceptError(ctx, requestEnv, response, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Copy Session Attributes into ResponseEnvelope
+	if session != nil {
+		responseEnv.SessionAttributes = make(map[string]interface{})
+		for n, v := range session.Attributes.String {
+			responseEnv.SessionAttributes[n] = v
+		}
+	}
+
+	// Alexa rejects dialog directives in a response that ends the session.
+	if response.ShouldSessionEnd && response.hasDialogDirective() {
+		logger.Debug("Keeping the session open for a dialog directive.")
+		response.ShouldSessionEnd = false
+	}
+
+	err = alexa.interceptResponse(ctx, requestEnv, responseEnv)
+	if err == nil && attributesManager != nil {
+		err = attributesManager.save(ctx)
+	}
+	if err != nil {
+		err = alexa.interceptError(ctx, requestEnv, response, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return responseEnv, nil
+}
+
+// dispatchRequest calls the RequestHandler methods for the request.
+func (alexa *Alexa) dispatchRequest(ctx context.Context, requestEnv *RequestEnvelope, response *Response) error {
+	request := requestEnv.Request
+	session := requestEnv.Session
+	context := requestEnv.Context
+	logger := LoggerFromContext(ctx)
+
 	// If it is a new session, invoke onSessionStarted
-	if session.New {
+	if session != nil && session.New {
 		err := alexa.RequestHandler.OnSessionStarted(ctx, request, session, context, response)
 		if err != nil {
-			log.Println("Error handling OnSessionStarted.", err.Error())
-			return nil, err
+			logger.Error("Error handling OnSessionStarted.", "err", err)
+			return err
 		}
 	}
 
-	switch requestEnv.Request.Type {
+	switch request.Type {
 	case launchRequestName:
 		err := alexa.RequestHandler.OnLaunch(ctx, request, session, context, response)
 		if err != nil {
-			log.Println("Error handling OnLaunch.", err.Error())
-			return nil, err
+			logger.Error("Error handling OnLaunch.", "err", err)
+			return err
 		}
 	case intentRequestName:
 		err := alexa.RequestHandler.OnIntent(ctx, request, session, context, response)
 		if err != nil {
-			log.Println("Error handling OnIntent.", err.Error())
-			return nil, err
+			logger.Error("Error handling OnIntent.", "err", err)
+			return err
 		}
 	case sessionEndedRequestName:
 		err := alexa.RequestHandler.OnSessionEnded(ctx, request, session, context, response)
 		if err != nil {
-			log.Println("Error handling OnSessionEnded.", err.Error())
-			return nil, err
+			logger.Error("Error handling OnSessionEnded.", "err", err)
+			return err
+		}
+	default:
+		err := alexa.dispatchRequestType(ctx, request, session, context, response)
+		if err != nil {
+			logger.Error("Error handling request.", "err", err)
+			return err
 		}
 	}
 
-	// Copy Session Attributes into ResponseEnvelope
-	responseEnv.SessionAttributes = make(map[string]interface{})
-	for n, v := range session.Attributes.String {
-		fmt.Println("Setting ", n, "to", v)
-		responseEnv.SessionAttributes[n] = v
-	}
-
-	return responseEnv, nil
+	return nil
 }
 
 // SetTimestampTolerance sets the maximum number of seconds to allow between
 // the current time and the request Timestamp.  Default value is 150 seconds.
+// Values above 150 seconds are ignored when CertificationMode is enabled.
 func (alexa *Alexa) SetTimestampTolerance(seconds int) {
-	timestampTolerance = seconds
+	alexa.timestampTolerance = seconds
+	alexa.timestampToleranceSet = true
+}
+
+// TimestampTolerance returns the maximum number of seconds to allow between
+// the current time and the request Timestamp.
+func (alexa *Alexa) TimestampTolerance() int {
+	tolerance := defaultTimestampTolerance
+	if alexa.timestampToleranceSet {
+		tolerance = alexa.timestampTolerance
+	}
+	if alexa.CertificationMode && tolerance > defaultTimestampTolerance {
+		tolerance = defaultTimestampTolerance
+	}
+	return tolerance
+}
+
+// now returns the current time from the Clock.
+func (alexa *Alexa) now() time.Time {
+	if alexa.Clock == nil {
+		return systemClock{}.Now()
+	}
+	return alexa.Clock.Now()
 }
 
 // SetSimpleCard creates a new simple card with the specified content.
@@ -315,6 +476,12 @@ func (r *Response) SetLinkAccountCard() {
 	r.Card = &Card{Type: "LinkAccount"}
 }
 
+// SetAskForPermissionsConsentCard creates a new AskForPermissionsConsent card
+// asking the user to grant the specified permissions.
+func (r *Response) SetAskForPermissionsConsentCard(permissions ...string) {
+	r.Card = &Card{Type: "AskForPermissionsConsent", Permissions: permissions}
+}
+
 // SetOutputText sets the OutputSpeech type to text and sets the value specified.
 func (r *Response) SetOutputText(text string) {
 	r.OutputSpeech = &OutputSpeech{Type: "PlainText", Text: text}
@@ -357,15 +524,15 @@ func (r *Response) AddAudioPlayer(playerType, playBehavior, streamToken, url str
 	r.Directives = append(r.Directives, d)
 }
 
-// AddDialogDirective adds a Dialog directive to the Response.
+// AddDialogDirective adds a Dialog directive to the Response. The typed
+// methods such as AddElicitSlotDirective also validate the directive.
 func (r *Response) AddDialogDirective(dialogType, slotToElicit, slotToConfirm string, intent *Intent) {
-	d := DialogDirective{
+	r.addDialogDirective(DialogDirective{
 		Type:          dialogType,
 		SlotToElicit:  slotToElicit,
 		SlotToConfirm: slotToConfirm,
 		UpdatedIntent: intent,
-	}
-	r.Directives = append(r.Directives, d)
+	})
 }
 
 // verifyApplicationId verifies that the ApplicationID sent in the request
@@ -376,7 +543,13 @@ func (alexa *Alexa) verifyApplicationID(request *RequestEnvelope) error {
 	}
 
 	appID := alexa.ApplicationID
-	requestAppID := request.Session.Application.ApplicationID
+	requestAppID := ""
+	if request.Session != nil {
+		requestAppID = request.Session.Application.ApplicationID
+	} else if request.Context != nil {
+		// Requests sent outside of a session only include the Application ID in the Context.
+		requestAppID = request.Context.System.Application.ApplicationID
+	}
 	if appID == "" {
 		return errors.New("application ID was set to an empty string")
 	}
@@ -401,11 +574,12 @@ func (alexa *Alexa) verifyTimestamp(request *RequestEnvelope) error {
 	if err != nil {
 		return errors.New("unable to parse request timestamp.  Err: " + err.Error())
 	}
-	now := time.Now()
+	now := alexa.now()
+	tolerance := alexa.TimestampTolerance()
 	delta := now.Sub(timestamp)
 	deltaSecsAbs := math.Abs(delta.Seconds())
-	if deltaSecsAbs > float64(timestampTolerance) {
-		return errors.New("invalid Timestamp. The request timestamp " + timestamp.String() + " was off the current time " + now.String() + " by more than " + strconv.FormatInt(int64(timestampTolerance), 10) + " seconds.")
+	if deltaSecsAbs > float64(tolerance) {
+		return errors.New("invalid Timestamp. The request timestamp " + timestamp.String() + " was off the current time " + now.String() + " by more than " + strconv.FormatInt(int64(tolerance), 10) + " seconds.")
 	}
 
 	return nil
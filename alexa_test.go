@@ -73,6 +73,40 @@ const recipeIntentString = `{
 	}
 }`
 
+const audioPlayerStartedString = `{
+	"version": "1.0",
+	"context": {
+		"AudioPlayer": {
+			"offsetInMilliseconds": 0,
+			"token": "track1",
+			"playerActivity": "PLAYING"
+		},
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.ABC123"
+			},
+			"user": {
+				"userId": "amzn1.ask.account.[unique-value-here]"
+			},
+			"device": {
+				"deviceId": "amzn1.ask.device.[unique-value-here]",
+				"supportedInterfaces": {
+					"AudioPlayer": {}
+				}
+			},
+			"apiEndpoint": "https://api.amazonalexa.com"
+		}
+	},
+	"request": {
+		"type": "AudioPlayer.PlaybackStarted",
+		"requestId": "amzn1.echo-api.request.[unique-value-here]",
+		"timestamp": "2019-10-04T17:36:04Z",
+		"locale": "en-US",
+		"token": "track1",
+		"offsetInMilliseconds": 0
+	}
+}`
+
 // TestAlexaJSON Verifies that the Alexa Struct parses an Alexa JSON String correctly.
 func TestAlexaJSON(t *testing.T) {
 	request := createRecipeRequest()
@@ -177,6 +211,52 @@ func TestAlexaTimestampValidation(t *testing.T) {
 
 }
 
+func TestAlexaTimestampClock(t *testing.T) {
+	request := createRecipeRequest()
+	request.Request.Timestamp = "2016-10-27T21:06:28Z"
+	frozen, _ := time.Parse(time.RFC3339, "2016-10-27T21:08:00Z")
+
+	alexa := getAlexa()
+	alexa.Clock = fixedClock(frozen)
+	ctx := context.Background()
+	_, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Error("Expected ProcessRequest to succeed with a frozen clock but got error", err)
+	}
+
+	alexa.Clock = fixedClock(frozen.Add(time.Minute))
+	_, err = alexa.ProcessRequest(ctx, request)
+	if err == nil {
+		t.Error("Expected ProcessRequest to fail to due to an invalid timetamp but no err was returned.")
+	}
+}
+
+func TestAlexaTimestampTolerancePerInstance(t *testing.T) {
+	strict := getAlexa()
+	strict.SetTimestampTolerance(10)
+	lenient := getAlexa()
+	lenient.SetTimestampTolerance(500)
+
+	if strict.TimestampTolerance() != 10 {
+		t.Errorf("Expected strict tolerance to be 10 but was %d", strict.TimestampTolerance())
+	}
+	if lenient.TimestampTolerance() != 500 {
+		t.Errorf("Expected lenient tolerance to be 500 but was %d", lenient.TimestampTolerance())
+	}
+	if getAlexa().TimestampTolerance() != 150 {
+		t.Errorf("Expected default tolerance to be 150 but was %d", getAlexa().TimestampTolerance())
+	}
+
+	lenient.CertificationMode = true
+	if lenient.TimestampTolerance() != 150 {
+		t.Errorf("Expected tolerance to be capped at 150 in certification mode but was %d", lenient.TimestampTolerance())
+	}
+	strict.CertificationMode = true
+	if strict.TimestampTolerance() != 10 {
+		t.Errorf("Expected tolerance below 150 to be kept in certification mode but was %d", strict.TimestampTolerance())
+	}
+}
+
 func TestAlexaOnSessionStartedCalled(t *testing.T) {
 	request := createRecipeRequest()
 
@@ -483,6 +563,72 @@ func TestNoIntentDialogDirective(t *testing.T) {
 	}
 }
 
+func TestAlexaSessionlessRequest(t *testing.T) {
+	var request RequestEnvelope
+	err := json.Unmarshal([]byte(audioPlayerStartedString), &request)
+	if err != nil {
+		t.Fatal("Error unmarshaling request.", err)
+	}
+	request.Request.Timestamp = time.Now().Format(time.RFC3339)
+
+	alexa := getAlexa()
+	var called bool
+	alexa.HandleRequestType(AudioPlayerPlaybackStarted, func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		called = true
+		if session != nil {
+			t.Error("Expected session to be nil for an AudioPlayer request.")
+		}
+		return nil
+	})
+	ctx := context.Background()
+	responseEnv, err := alexa.ProcessRequest(ctx, &request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if !called {
+		t.Error("AudioPlayer.PlaybackStarted handler was not called.")
+	}
+
+	b, err := json.Marshal(responseEnv)
+	if err != nil {
+		t.Fatalf("Error marshaling response. %s", err.Error())
+	}
+	exp := `{"version":"1.0","response":{}}`
+	if string(b) != exp {
+		t.Errorf("Expected JSON of "+exp+" but was %s", string(b))
+	}
+
+	request.Context.System.Application.ApplicationID = "amzn1.ask.skill.OTHER"
+	_, err = alexa.ProcessRequest(ctx, &request)
+	if err == nil {
+		t.Error("Expected ProcessRequest to fail due to an invalid Context Application ID but no err was returned.")
+	}
+
+	_, err = alexa.ProcessRequest(ctx, &RequestEnvelope{})
+	if err != ErrRequestNil {
+		t.Error("Expected ProcessRequest to fail with ErrRequestNil but got", err)
+	}
+}
+
+func TestAlexaSessionResponseIncludesShouldEndSession(t *testing.T) {
+	request := createRecipeRequest()
+
+	alexa := getAlexa()
+	responseEnv, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+
+	b, err := json.Marshal(responseEnv)
+	if err != nil {
+		t.Fatalf("Error marshaling response. %s", err.Error())
+	}
+	exp := `{"version":"1.0","response":{"shouldEndSession":true}}`
+	if string(b) != exp {
+		t.Errorf("Expected JSON of "+exp+" but was %s", string(b))
+	}
+}
+
 func getAlexa() *Alexa {
 	return &Alexa{ApplicationID: applicationID, RequestHandler: &emptyRequestHandler{}}
 }
@@ -499,6 +645,12 @@ func createRecipeRequest() *RequestEnvelope {
 	return &request
 }
 
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
 type emptyRequestHandler struct {
 	OnSessionStartedCalled  bool
 	OnSessionStartThrowsErr bool
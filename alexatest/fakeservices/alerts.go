@@ -0,0 +1,277 @@
+package fakeservices
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	alexa "github.com/ericdaugherty/alexa-skills-kit-golang"
+)
+
+const alertTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// Reminders returns the reminders created on the Server, in creation order.
+func (s *Server) Reminders() []alexa.ReminderResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reminders := make([]alexa.ReminderResponse, 0, len(s.reminderOrder))
+	for _, token := range s.reminderOrder {
+		reminders = append(reminders, copyReminder(s.reminders[token]))
+	}
+	return reminders
+}
+
+// Timers returns the timers created on the Server, in creation order.
+func (s *Server) Timers() []alexa.Timer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timers := make([]alexa.Timer, 0, len(s.timerOrder))
+	for _, id := range s.timerOrder {
+		timers = append(timers, *s.timers[id])
+	}
+	return timers
+}
+
+// copyReminder returns a copy of r that shares no slices or pointers with it.
+func copyReminder(r *alexa.ReminderResponse) alexa.ReminderResponse {
+	c := *r
+	if r.Trigger.Recurrence != nil {
+		recurrence := *r.Trigger.Recurrence
+		recurrence.RecurrenceRules = append([]string(nil), recurrence.RecurrenceRules...)
+		c.Trigger.Recurrence = &recurrence
+	}
+	c.AlertInfo.SpokenInfo.Content = append([]alexa.SpokenContent(nil), r.AlertInfo.SpokenInfo.Content...)
+	return c
+}
+
+func (s *Server) registerReminders(mux *router) {
+	permitted := func(h http.HandlerFunc) http.HandlerFunc {
+		return s.permitted(alexa.PermissionRemindersReadWrite, http.StatusUnauthorized, h)
+	}
+	mux.HandleFunc("POST /v1/alerts/reminders", permitted(s.createReminder))
+	mux.HandleFunc("GET /v1/alerts/reminders", permitted(s.listReminders))
+	mux.HandleFunc("GET /v1/alerts/reminders/{token}", permitted(s.getReminder))
+	mux.HandleFunc("PUT /v1/alerts/reminders/{token}", permitted(s.updateReminder))
+	mux.HandleFunc("DELETE /v1/alerts/reminders/{token}", permitted(s.deleteReminder))
+}
+
+func (s *Server) createReminder(w http.ResponseWriter, r *http.Request) {
+	var reminder alexa.Reminder
+	if !decode(w, r, &reminder) {
+		return
+	}
+	s.mu.Lock()
+	token := s.newID("reminder")
+	now := time.Now().UTC().Format(alertTimeLayout)
+	resp := &alexa.ReminderResponse{
+		AlertToken:       token,
+		CreatedTime:      now,
+		UpdatedTime:      now,
+		Status:           "ON",
+		Version:          "1",
+		Href:             "/v1/alerts/reminders/" + token,
+		Trigger:          reminder.Trigger,
+		AlertInfo:        reminder.AlertInfo,
+		PushNotification: reminder.PushNotification,
+	}
+	s.reminders[token] = resp
+	s.reminderOrder = append(s.reminderOrder, token)
+	result := copyReminder(resp)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listReminders(w http.ResponseWriter, r *http.Request) {
+	reminders := s.Reminders()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"totalCount": strconv.Itoa(len(reminders)),
+		"alerts":     reminders,
+		"links":      map[string]interface{}{},
+	})
+}
+
+func (s *Server) getReminder(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	resp, ok := s.reminders[pathValue(r, "token")]
+	var result alexa.ReminderResponse
+	if ok {
+		result = copyReminder(resp)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The reminder does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) updateReminder(w http.ResponseWriter, r *http.Request) {
+	var reminder alexa.Reminder
+	if !decode(w, r, &reminder) {
+		return
+	}
+	s.mu.Lock()
+	resp, ok := s.reminders[pathValue(r, "token")]
+	var result alexa.ReminderResponse
+	if ok {
+		version, _ := strconv.Atoi(resp.Version)
+		resp.Version = strconv.Itoa(version + 1)
+		resp.UpdatedTime = time.Now().UTC().Format(alertTimeLayout)
+		resp.Trigger = reminder.Trigger
+		resp.AlertInfo = reminder.AlertInfo
+		resp.PushNotification = reminder.PushNotification
+		result = copyReminder(resp)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The reminder does not exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) deleteReminder(w http.ResponseWriter, r *http.Request) {
+	token := pathValue(r, "token")
+	s.mu.Lock()
+	_, ok := s.reminders[token]
+	if ok {
+		delete(s.reminders, token)
+		s.reminderOrder = remove(s.reminderOrder, token)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The reminder does not exist.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) registerTimers(mux *router) {
+	permitted := func(h http.HandlerFunc) http.HandlerFunc {
+		return s.permitted(alexa.PermissionTimersReadWrite, http.StatusUnauthorized, h)
+	}
+	mux.HandleFunc("POST /v1/alerts/timers", permitted(s.createTimer))
+	mux.HandleFunc("GET /v1/alerts/timers", permitted(s.listTimers))
+	mux.HandleFunc("DELETE /v1/alerts/timers", permitted(s.deleteTimers))
+	mux.HandleFunc("GET /v1/alerts/timers/{id}", permitted(s.timerHandler(nil)))
+	mux.HandleFunc("DELETE /v1/alerts/timers/{id}", permitted(s.deleteTimer))
+	mux.HandleFunc("POST /v1/alerts/timers/{id}/pause", permitted(s.timerHandler(pauseTimer)))
+	mux.HandleFunc("POST /v1/alerts/timers/{id}/resume", permitted(s.timerHandler(resumeTimer)))
+}
+
+func (s *Server) createTimer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Duration   alexa.ISODuration `json:"duration"`
+		TimerLabel string            `json:"timerLabel"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Duration <= 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_DURATION", "The timer duration must be positive.")
+		return
+	}
+	s.mu.Lock()
+	now := time.Now().UTC()
+	timer := &alexa.Timer{
+		ID:          s.newID("timer"),
+		Status:      alexa.TimerStatusOn,
+		Duration:    req.Duration,
+		TimerLabel:  req.TimerLabel,
+		TriggerTime: now.Add(time.Duration(req.Duration)).Format(alertTimeLayout),
+		CreatedTime: now.Format(alertTimeLayout),
+		UpdatedTime: now.Format(alertTimeLayout),
+	}
+	s.timers[timer.ID] = timer
+	s.timerOrder = append(s.timerOrder, timer.ID)
+	result := *timer
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listTimers(w http.ResponseWriter, r *http.Request) {
+	timers := s.Timers()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"timers":     timers,
+		"totalCount": len(timers),
+		"nextToken":  nil,
+	})
+}
+
+// timerHandler applies update, if not nil, to the timer and writes it.
+func (s *Server) timerHandler(update func(*alexa.Timer, time.Time)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		timer, ok := s.timers[pathValue(r, "id")]
+		var result alexa.Timer
+		if ok {
+			if update != nil {
+				update(timer, time.Now().UTC())
+			}
+			result = *timer
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "TIMER_NOT_FOUND", "The timer does not exist.")
+			return
+		}
+		if update != nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func pauseTimer(timer *alexa.Timer, now time.Time) {
+	if timer.Status != alexa.TimerStatusOn {
+		return
+	}
+	trigger, _ := time.Parse(alertTimeLayout, timer.TriggerTime)
+	timer.Status = alexa.TimerStatusPaused
+	timer.RemainingTimeWhenPaused = alexa.ISODuration(trigger.Sub(now))
+	timer.UpdatedTime = now.Format(alertTimeLayout)
+}
+
+func resumeTimer(timer *alexa.Timer, now time.Time) {
+	if timer.Status != alexa.TimerStatusPaused {
+		return
+	}
+	timer.Status = alexa.TimerStatusOn
+	timer.TriggerTime = now.Add(time.Duration(timer.RemainingTimeWhenPaused)).Format(alertTimeLayout)
+	timer.RemainingTimeWhenPaused = 0
+	timer.UpdatedTime = now.Format(alertTimeLayout)
+}
+
+func (s *Server) deleteTimer(w http.ResponseWriter, r *http.Request) {
+	id := pathValue(r, "id")
+	s.mu.Lock()
+	_, ok := s.timers[id]
+	if ok {
+		delete(s.timers, id)
+		s.timerOrder = remove(s.timerOrder, id)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "TIMER_NOT_FOUND", "The timer does not exist.")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteTimers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.timers = make(map[string]*alexa.Timer)
+	s.timerOrder = nil
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func remove(ids []string, id string) []string {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
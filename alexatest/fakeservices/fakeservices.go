@@ -0,0 +1,393 @@
+// Package fakeservices provides a local stand-in for the Alexa service APIs,
+// so that skills calling them can be tested without network access.
+//
+// A Server emulates the directive, device address, customer profile, settings,
+// reminders, household lists and timers endpoints. Its state can be set up
+// before a test, and every call is recorded so it can be inspected afterwards.
+//
+//	services := fakeservices.NewServer()
+//	defer services.Close()
+//	services.Wire(requestEnv)
+//	responseEnv, err := a.ProcessRequest(ctx, requestEnv)
+//	calls := services.Calls()
+package fakeservices
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+
+	alexa "github.com/ericdaugherty/alexa-skills-kit-golang"
+)
+
+// DefaultAccessToken is the API access token the Server accepts by default.
+const DefaultAccessToken = "fake-api-access-token"
+
+// DefaultDeviceID is the device ID set by Wire when the request has none.
+const DefaultDeviceID = "fake-device-id"
+
+// Call is a request received by the Server.
+type Call struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Directive is a directive sent to the Directive Service.
+type Directive struct {
+	RequestID string
+	Type      string
+	Speech    string
+}
+
+// Profile is the customer profile returned by the Server. Empty fields are
+// returned as not set.
+type Profile struct {
+	Name         string
+	GivenName    string
+	Email        string
+	MobileNumber *alexa.PhoneNumber
+}
+
+// Settings are the device settings returned by the Server.
+type Settings struct {
+	TimeZone        string
+	DistanceUnits   string
+	TemperatureUnit string
+}
+
+// Server is an httptest.Server emulating the Alexa service APIs. It is safe
+// for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	router        *router
+	mu            sync.Mutex
+	token         string
+	calls         []Call
+	failures      []int
+	revoked       map[string]bool
+	directives    []Directive
+	address       alexa.Address
+	profile       Profile
+	personProfile Profile
+	settings      Settings
+	reminders     map[string]*alexa.ReminderResponse
+	reminderOrder []string
+	lists         map[string]*list
+	listOrder     []string
+	timers        map[string]*alexa.Timer
+	timerOrder    []string
+	nextID        int
+}
+
+// NewServer starts a Server. It has an Alexa shopping list and an Alexa
+// to-do list, Pacific time zone settings, and every permission granted.
+func NewServer() *Server {
+	s := &Server{
+		token:     DefaultAccessToken,
+		revoked:   make(map[string]bool),
+		reminders: make(map[string]*alexa.ReminderResponse),
+		lists:     make(map[string]*list),
+		timers:    make(map[string]*alexa.Timer),
+		settings: Settings{
+			TimeZone:        "America/Los_Angeles",
+			DistanceUnits:   alexa.DistanceUnitsImperial,
+			TemperatureUnit: alexa.TemperatureUnitFahrenheit,
+		},
+	}
+	s.addList("Alexa shopping list")
+	s.addList("Alexa to-do list")
+	s.router = s.routes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Wire points the Context of requestEnv at the Server, so that clients
+// created from it call the Server. A Context is created if there is none, and
+// the device ID is set to DefaultDeviceID if it is empty.
+func (s *Server) Wire(requestEnv *alexa.RequestEnvelope) {
+	if requestEnv.Context == nil {
+		requestEnv.Context = &alexa.Context{}
+	}
+	if requestEnv.Context.System.Device.DeviceID == "" {
+		requestEnv.Context.System.Device.DeviceID = DefaultDeviceID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requestEnv.Context.System.APIEndpoint = s.URL
+	requestEnv.Context.System.APIAccessToken = s.token
+}
+
+// SetAccessToken sets the API access token the Server accepts. Requests with
+// any other token receive a 401 response.
+func (s *Server) SetAccessToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// Calls returns the requests received by the Server, in order.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// FailNext makes the next requests fail with the given statuses, one status
+// per request, before the Server resumes normal responses.
+func (s *Server) FailNext(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// RevokePermission makes the endpoints that require permission, such as
+// alexa.PermissionReadAddress, respond as if the user had not granted it.
+func (s *Server) RevokePermission(permission string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[permission] = true
+}
+
+// GrantPermission grants a permission removed with RevokePermission.
+func (s *Server) GrantPermission(permission string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.revoked, permission)
+}
+
+// Directives returns the directives sent to the Directive Service.
+func (s *Server) Directives() []Directive {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Directive(nil), s.directives...)
+}
+
+// SetAddress sets the address returned for every device.
+func (s *Server) SetAddress(address alexa.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.address = address
+}
+
+// SetProfile sets the profile of the account owner.
+func (s *Server) SetProfile(profile Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profile = copyProfile(profile)
+}
+
+// SetPersonProfile sets the profile of the recognized speaker.
+func (s *Server) SetPersonProfile(profile Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.personProfile = copyProfile(profile)
+}
+
+// copyProfile returns a copy of p that does not share its MobileNumber.
+func copyProfile(p Profile) Profile {
+	if p.MobileNumber != nil {
+		number := *p.MobileNumber
+		p.MobileNumber = &number
+	}
+	return p
+}
+
+// SetSettings sets the settings returned for every device.
+func (s *Server) SetSettings(settings Settings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header.Clone(), Body: body})
+	token := s.token
+	failure := 0
+	if len(s.failures) > 0 {
+		failure = s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+token {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "The access token is not valid.")
+		return
+	}
+	if failure != 0 {
+		writeError(w, failure, "FAKE_FAILURE", "Failure requested with FailNext.")
+		return
+	}
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() *router {
+	mux := &router{}
+	mux.HandleFunc("POST /v1/directives", s.postDirective)
+
+	mux.HandleFunc("GET /v1/devices/{deviceId}/settings/address", s.permitted(alexa.PermissionReadAddress, http.StatusForbidden, s.getAddress))
+	mux.HandleFunc("GET /v1/devices/{deviceId}/settings/address/countryAndPostalCode", s.permitted(alexa.PermissionReadCountryAndPostalCode, http.StatusForbidden, s.getCountryAndPostalCode))
+
+	profiles := []struct {
+		path, permission string
+		person           bool
+		field            func(Profile) interface{}
+	}{
+		{"/v2/accounts/~current/settings/Profile.name", alexa.PermissionReadName, false, func(p Profile) interface{} { return p.Name }},
+		{"/v2/accounts/~current/settings/Profile.givenName", alexa.PermissionReadGivenName, false, func(p Profile) interface{} { return p.GivenName }},
+		{"/v2/accounts/~current/settings/Profile.email", alexa.PermissionReadEmail, false, func(p Profile) interface{} { return p.Email }},
+		{"/v2/accounts/~current/settings/Profile.mobileNumber", alexa.PermissionReadMobileNumber, false, func(p Profile) interface{} { return p.MobileNumber }},
+		{"/v2/persons/~current/profile/name", alexa.PermissionReadName, true, func(p Profile) interface{} { return p.Name }},
+		{"/v2/persons/~current/profile/givenName", alexa.PermissionReadGivenName, true, func(p Profile) interface{} { return p.GivenName }},
+		{"/v2/persons/~current/profile/mobileNumber", alexa.PermissionReadMobileNumber, true, func(p Profile) interface{} { return p.MobileNumber }},
+	}
+	for _, p := range profiles {
+		p := p
+		mux.HandleFunc("GET "+p.path, s.permitted(p.permission, http.StatusForbidden, func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			profile := s.profile
+			if p.person {
+				profile = s.personProfile
+			}
+			s.mu.Unlock()
+			writeProfileField(w, p.field(profile))
+		}))
+	}
+
+	mux.HandleFunc("GET /v2/devices/{deviceId}/settings/{setting}", s.getSetting)
+
+	s.registerReminders(mux)
+	s.registerLists(mux)
+	s.registerTimers(mux)
+	return mux
+}
+
+// permitted responds with status if permission has been revoked, and
+// otherwise calls handler.
+func (s *Server) permitted(permission string, status int, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		revoked := s.revoked[permission]
+		s.mu.Unlock()
+		if revoked {
+			writeError(w, status, "ACCESS_DENIED", "The user has not granted "+permission+".")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) postDirective(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Header struct {
+			RequestID string `json:"requestId"`
+		} `json:"header"`
+		Directive struct {
+			Type   string `json:"type"`
+			Speech string `json:"speech"`
+		} `json:"directive"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Header.RequestID == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_DIRECTIVE", "The requestId is missing.")
+		return
+	}
+	s.mu.Lock()
+	s.directives = append(s.directives, Directive{RequestID: req.Header.RequestID, Type: req.Directive.Type, Speech: req.Directive.Speech})
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getAddress(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	address := s.address
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, address)
+}
+
+func (s *Server) getCountryAndPostalCode(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	address := alexa.CountryAndPostalCode{CountryCode: s.address.CountryCode, PostalCode: s.address.PostalCode}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, address)
+}
+
+func (s *Server) getSetting(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	settings := s.settings
+	s.mu.Unlock()
+
+	var value string
+	switch pathValue(r, "setting") {
+	case "System.timeZone":
+		value = settings.TimeZone
+	case "System.distanceUnits":
+		value = settings.DistanceUnits
+	case "System.temperatureUnit":
+		value = settings.TemperatureUnit
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown setting "+pathValue(r, "setting")+".")
+		return
+	}
+	if value == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, value)
+}
+
+// writeProfileField writes a profile value, or 204 No Content if it is not set.
+func writeProfileField(w http.ResponseWriter, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	case *alexa.PhoneNumber:
+		if v == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, value)
+}
+
+// newID returns a new identifier with prefix. It must be called with s.mu held.
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return prefix + "-" + strconv.Itoa(s.nextID)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
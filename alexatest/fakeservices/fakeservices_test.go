@@ -0,0 +1,333 @@
+package fakeservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	alexa "github.com/ericdaugherty/alexa-skills-kit-golang"
+)
+
+func newWiredContext(t *testing.T) (*Server, *alexa.Context) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+	requestEnv := &alexa.RequestEnvelope{}
+	s.Wire(requestEnv)
+	return s, requestEnv.Context
+}
+
+func TestWireProcessRequest(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	requestEnv := &alexa.RequestEnvelope{
+		Version: "1.0",
+		Request: &alexa.Request{Type: "IntentRequest", RequestID: "request-1", Timestamp: time.Now().Format(time.RFC3339)},
+		Context: &alexa.Context{},
+	}
+	requestEnv.Request.Intent.Name = "RecipeIntent"
+	s.Wire(requestEnv)
+	s.SetProfile(Profile{GivenName: "Jane"})
+
+	router := &alexa.IntentRouter{}
+	router.HandleIntent("RecipeIntent", func(ctx context.Context, request *alexa.Request, session *alexa.Session, aContext *alexa.Context, response *alexa.Response) error {
+		err := alexa.NewDirectiveClient(aContext).Speak(ctx, request.RequestID, "One moment.")
+		if err != nil {
+			return err
+		}
+		name, err := alexa.NewProfileClient(aContext).GivenName(ctx)
+		if err != nil {
+			return err
+		}
+		response.SetOutputText("Hello " + name)
+		return nil
+	})
+	a := &alexa.Alexa{IgnoreApplicationID: true, RequestHandler: router}
+
+	responseEnv, err := a.ProcessRequest(context.Background(), requestEnv)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if text := responseEnv.Response.OutputSpeech.Text; text != "Hello Jane" {
+		t.Errorf("Expected Hello Jane but was %s", text)
+	}
+	directives := s.Directives()
+	if len(directives) != 1 || directives[0] != (Directive{RequestID: "request-1", Type: "VoicePlayer.Speak", Speech: "One moment."}) {
+		t.Errorf("Unexpected directives %+v", directives)
+	}
+	calls := s.Calls()
+	if len(calls) != 2 || calls[0].Path != "/v1/directives" || calls[1].Path != "/v2/accounts/~current/settings/Profile.givenName" {
+		t.Errorf("Unexpected calls %+v", calls)
+	}
+}
+
+func TestWireDeviceID(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	requestEnv := &alexa.RequestEnvelope{}
+	s.Wire(requestEnv)
+	if id := requestEnv.Context.System.Device.DeviceID; id != DefaultDeviceID {
+		t.Errorf("Expected the default device ID but was %s", id)
+	}
+	requestEnv.Context.System.Device.DeviceID = "device-1"
+	s.Wire(requestEnv)
+	if id := requestEnv.Context.System.Device.DeviceID; id != "device-1" {
+		t.Errorf("Expected Wire to keep device-1 but was %s", id)
+	}
+}
+
+func TestAccessorsReturnCopies(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+
+	reminder, err := alexa.NewReminder().At(time.Now().Add(time.Hour), "").Recurring(time.Time{}, time.Time{}, "FREQ=DAILY").Text("en-US", "Stretch").Build()
+	if err != nil {
+		t.Fatal("Error building reminder. " + err.Error())
+	}
+	_, err = alexa.NewRemindersClient(aContext).Create(ctx, reminder)
+	if err != nil {
+		t.Fatal("Error creating reminder. " + err.Error())
+	}
+	reminders := s.Reminders()
+	reminders[0].AlertInfo.SpokenInfo.Content[0].Text = "changed"
+	reminders[0].Trigger.Recurrence.RecurrenceRules[0] = "changed"
+	reminders = s.Reminders()
+	if reminders[0].AlertInfo.SpokenInfo.Content[0].Text != "Stretch" || reminders[0].Trigger.Recurrence.RecurrenceRules[0] != "FREQ=DAILY" {
+		t.Errorf("Expected the server reminder to be unchanged but was %+v", reminders[0])
+	}
+
+	listID := s.Lists()[0].ListID
+	s.AddListItem(listID, "milk", alexa.ListItemActive)
+	s.Lists()[0].Items[0].Value = "changed"
+	if value := s.Lists()[0].Items[0].Value; value != "milk" {
+		t.Errorf("Expected the server list item to be unchanged but was %s", value)
+	}
+
+	_, err = alexa.NewTimersClient(aContext).Create(ctx, alexa.NewNotifyOnlyTimer(time.Minute, "eggs"))
+	if err != nil {
+		t.Fatal("Error creating timer. " + err.Error())
+	}
+	s.Timers()[0].TimerLabel = "changed"
+	if label := s.Timers()[0].TimerLabel; label != "eggs" {
+		t.Errorf("Expected the server timer to be unchanged but was %s", label)
+	}
+
+	number := &alexa.PhoneNumber{CountryCode: "+1", PhoneNumber: "5555550100"}
+	s.SetProfile(Profile{MobileNumber: number})
+	number.PhoneNumber = "changed"
+	if got, err := alexa.NewProfileClient(aContext).MobileNumber(ctx); err != nil || got.PhoneNumber != "5555550100" {
+		t.Errorf("Expected the server profile to be unchanged but was %+v, %v", got, err)
+	}
+}
+
+func TestAddressProfileAndSettings(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+	s.SetAddress(alexa.Address{City: "Seattle", CountryCode: "US", PostalCode: "98109"})
+	s.SetProfile(Profile{Name: "Jane Doe", Email: "jane@example.com", MobileNumber: &alexa.PhoneNumber{CountryCode: "+1", PhoneNumber: "5555550100"}})
+	s.SetPersonProfile(Profile{GivenName: "John"})
+
+	address, err := alexa.NewAddressClient(aContext).Address(ctx)
+	if err != nil || address.City != "Seattle" {
+		t.Errorf("Unexpected address %+v, %v", address, err)
+	}
+	postal, err := alexa.NewAddressClient(aContext).CountryAndPostalCode(ctx)
+	if err != nil || postal.PostalCode != "98109" {
+		t.Errorf("Unexpected postal code %+v, %v", postal, err)
+	}
+
+	profile := alexa.NewProfileClient(aContext)
+	if name, err := profile.Name(ctx); err != nil || name != "Jane Doe" {
+		t.Errorf("Unexpected name %s, %v", name, err)
+	}
+	if number, err := profile.MobileNumber(ctx); err != nil || number.PhoneNumber != "5555550100" {
+		t.Errorf("Unexpected mobile number %+v, %v", number, err)
+	}
+	if name, err := profile.GivenName(ctx); err != nil || name != "" {
+		t.Errorf("Expected an unset given name but was %s, %v", name, err)
+	}
+	if name, err := profile.PersonGivenName(ctx); err != nil || name != "John" {
+		t.Errorf("Unexpected person given name %s, %v", name, err)
+	}
+
+	local, err := alexa.NewSettingsClient(aContext).RequestTime(ctx, &alexa.Request{Timestamp: "2024-07-01T16:30:00Z"})
+	if err != nil || local.Hour() != 9 {
+		t.Errorf("Unexpected local request time %s, %v", local, err)
+	}
+}
+
+func TestRevokePermission(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+
+	s.RevokePermission(alexa.PermissionReadAddress)
+	_, err := alexa.NewAddressClient(aContext).Address(ctx)
+	if !errors.Is(err, alexa.ErrPermissionNotGranted) {
+		t.Errorf("Expected ErrPermissionNotGranted but was %v", err)
+	}
+	_, err = alexa.NewAddressClient(aContext).CountryAndPostalCode(ctx)
+	if err != nil {
+		t.Errorf("Expected the country and postal code to be permitted but was %v", err)
+	}
+
+	s.RevokePermission(alexa.PermissionTimersReadWrite)
+	_, err = alexa.NewTimersClient(aContext).List(ctx)
+	var serviceErr *alexa.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.StatusCode != http.StatusUnauthorized || !errors.Is(err, alexa.ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 ServiceError matching ErrPermissionNotGranted but was %v", err)
+	}
+
+	s.GrantPermission(alexa.PermissionReadAddress)
+	_, err = alexa.NewAddressClient(aContext).Address(ctx)
+	if err != nil {
+		t.Errorf("Expected the address to be permitted again but was %v", err)
+	}
+}
+
+func TestAccessTokenAndFailures(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+
+	s.FailNext(http.StatusServiceUnavailable)
+	_, err := alexa.NewSettingsClient(aContext).TimeZone(ctx)
+	if err != nil {
+		t.Errorf("Expected the client to retry a 503 but was %v", err)
+	}
+	if calls := s.Calls(); len(calls) != 2 {
+		t.Errorf("Expected 2 calls but was %d", len(calls))
+	}
+
+	s.SetAccessToken("other-token")
+	err = alexa.NewDirectiveClient(aContext).Speak(ctx, "request-1", "Hello")
+	var serviceErr *alexa.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.StatusCode != http.StatusUnauthorized || errors.Is(err, alexa.ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 ServiceError not matching ErrPermissionNotGranted but was %v", err)
+	}
+}
+
+func TestReminders(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+	client := alexa.NewRemindersClient(aContext)
+
+	reminder, err := alexa.NewReminder().In(time.Hour).Text("en-US", "Stretch").Build()
+	if err != nil {
+		t.Fatal("Error building reminder. " + err.Error())
+	}
+	created, err := client.Create(ctx, reminder)
+	if err != nil {
+		t.Fatal("Error creating reminder. " + err.Error())
+	}
+	updated, err := client.Update(ctx, created.AlertToken, reminder)
+	if err != nil || updated.Version != "2" {
+		t.Errorf("Unexpected update %+v, %v", updated, err)
+	}
+	list, err := client.List(ctx)
+	if err != nil || list.TotalCount != "1" {
+		t.Errorf("Unexpected reminder list %+v, %v", list, err)
+	}
+	if reminders := s.Reminders(); len(reminders) != 1 || reminders[0].AlertInfo.SpokenInfo.Content[0].Text != "Stretch" {
+		t.Errorf("Unexpected reminders %+v", reminders)
+	}
+	err = client.Delete(ctx, created.AlertToken)
+	if err != nil {
+		t.Fatal("Error deleting reminder. " + err.Error())
+	}
+	_, err = client.Get(ctx, created.AlertToken)
+	var serviceErr *alexa.ServiceError
+	if !errors.As(err, &serviceErr) || serviceErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a 404 for a deleted reminder but was %v", err)
+	}
+}
+
+func TestLists(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+	client := alexa.NewListsClient(aContext)
+
+	lists, err := client.Lists(ctx)
+	if err != nil || len(lists) != 2 || lists[0].Name != "Alexa shopping list" {
+		t.Fatalf("Unexpected lists %+v, %v", lists, err)
+	}
+	shopping := lists[0].ListID
+	s.AddListItem(shopping, "milk", alexa.ListItemActive)
+
+	item, err := client.CreateItem(ctx, shopping, "eggs", alexa.ListItemActive)
+	if err != nil {
+		t.Fatal("Error creating item. " + err.Error())
+	}
+	_, err = client.UpdateItem(ctx, shopping, item.ID, "eggs", alexa.ListItemCompleted, item.Version)
+	if err != nil {
+		t.Fatal("Error updating item. " + err.Error())
+	}
+	_, err = client.UpdateItem(ctx, shopping, item.ID, "eggs", alexa.ListItemActive, item.Version)
+	if err == nil {
+		t.Error("Expected a conflict updating with an old version.")
+	}
+
+	active, err := client.List(ctx, shopping, alexa.ListItemActive)
+	if err != nil || len(active.Items) != 1 || active.Items[0].Value != "milk" {
+		t.Errorf("Unexpected active items %+v, %v", active, err)
+	}
+	completed, err := client.List(ctx, shopping, alexa.ListItemCompleted)
+	if err != nil || len(completed.Items) != 1 || completed.Items[0].Value != "eggs" {
+		t.Errorf("Unexpected completed items %+v, %v", completed, err)
+	}
+
+	camping, err := client.CreateList(ctx, "Camping")
+	if err != nil {
+		t.Fatal("Error creating list. " + err.Error())
+	}
+	err = client.DeleteList(ctx, camping.ListID)
+	if err != nil {
+		t.Fatal("Error deleting list. " + err.Error())
+	}
+	if lists := s.Lists(); len(lists) != 2 || len(lists[0].Items) != 2 {
+		t.Errorf("Unexpected server lists %+v", lists)
+	}
+}
+
+func TestTimers(t *testing.T) {
+	s, aContext := newWiredContext(t)
+	ctx := context.Background()
+	client := alexa.NewTimersClient(aContext)
+
+	timer, err := client.Create(ctx, alexa.NewNotifyOnlyTimer(10*time.Minute, "pasta"))
+	if err != nil {
+		t.Fatal("Error creating timer. " + err.Error())
+	}
+	if timer.Status != alexa.TimerStatusOn || time.Duration(timer.Duration) != 10*time.Minute {
+		t.Errorf("Unexpected timer %+v", timer)
+	}
+
+	err = client.Pause(ctx, timer.ID)
+	if err != nil {
+		t.Fatal("Error pausing timer. " + err.Error())
+	}
+	paused, err := client.Get(ctx, timer.ID)
+	if err != nil || paused.Status != alexa.TimerStatusPaused || paused.RemainingTimeWhenPaused <= 0 {
+		t.Errorf("Unexpected paused timer %+v, %v", paused, err)
+	}
+	err = client.Resume(ctx, timer.ID)
+	if err != nil {
+		t.Fatal("Error resuming timer. " + err.Error())
+	}
+	if timers := s.Timers(); len(timers) != 1 || timers[0].Status != alexa.TimerStatusOn {
+		t.Errorf("Unexpected timers %+v", timers)
+	}
+
+	_, err = client.Create(ctx, alexa.NewNotifyOnlyTimer(time.Minute, "eggs"))
+	if err != nil {
+		t.Fatal("Error creating timer. " + err.Error())
+	}
+	err = client.DeleteAll(ctx)
+	if err != nil {
+		t.Fatal("Error deleting timers. " + err.Error())
+	}
+	if timers, err := client.List(ctx); err != nil || len(timers) != 0 {
+		t.Errorf("Expected no timers but was %+v, %v", timers, err)
+	}
+}
@@ -0,0 +1,258 @@
+package fakeservices
+
+import (
+	"net/http"
+	"time"
+
+	alexa "github.com/ericdaugherty/alexa-skills-kit-golang"
+)
+
+type list struct {
+	metadata alexa.ListMetadata
+	items    []*alexa.ListItem
+}
+
+// AddList adds an active list named name and returns its ID.
+func (s *Server) AddList(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addList(name)
+}
+
+// AddListItem adds an item to the list with listID and returns the item ID,
+// or an empty string if the list does not exist.
+func (s *Server) AddListItem(listID, value, status string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.lists[listID]
+	if !ok {
+		return ""
+	}
+	return s.addListItem(l, value, status).ID
+}
+
+// Lists returns the lists on the Server with their items of every status.
+func (s *Server) Lists() []alexa.List {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lists := make([]alexa.List, 0, len(s.listOrder))
+	for _, id := range s.listOrder {
+		l := s.lists[id]
+		lists = append(lists, l.list(""))
+	}
+	return lists
+}
+
+// addList must be called with s.mu held.
+func (s *Server) addList(name string) string {
+	id := s.newID("list")
+	l := &list{metadata: alexa.ListMetadata{ListID: id, Name: name, State: alexa.ListStateActive, Version: 1}}
+	for _, status := range []string{alexa.ListItemActive, alexa.ListItemCompleted} {
+		l.metadata.StatusMap = append(l.metadata.StatusMap, alexa.ListStatus{Href: "/v2/householdlists/" + id + "/" + status, Status: status})
+	}
+	s.lists[id] = l
+	s.listOrder = append(s.listOrder, id)
+	return id
+}
+
+// addListItem must be called with s.mu held.
+func (s *Server) addListItem(l *list, value, status string) *alexa.ListItem {
+	id := s.newID("item")
+	now := time.Now().UTC().Format(alertTimeLayout)
+	item := &alexa.ListItem{
+		ID:          id,
+		Version:     1,
+		Value:       value,
+		Status:      status,
+		CreatedTime: now,
+		UpdatedTime: now,
+		Href:        "/v2/householdlists/" + l.metadata.ListID + "/items/" + id,
+	}
+	l.items = append(l.items, item)
+	return item
+}
+
+// list returns the list with its items of status, or every item if status is empty.
+func (l *list) list(status string) alexa.List {
+	result := alexa.List{ListID: l.metadata.ListID, Name: l.metadata.Name, State: l.metadata.State, Version: l.metadata.Version}
+	result.Items = []alexa.ListItem{}
+	for _, item := range l.items {
+		if status == "" || item.Status == status {
+			result.Items = append(result.Items, *item)
+		}
+	}
+	return result
+}
+
+func (l *list) item(id string) *alexa.ListItem {
+	for _, item := range l.items {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *Server) registerLists(mux *router) {
+	read := func(h http.HandlerFunc) http.HandlerFunc {
+		return s.permitted(alexa.PermissionReadHouseholdList, http.StatusForbidden, h)
+	}
+	write := func(h http.HandlerFunc) http.HandlerFunc {
+		return s.permitted(alexa.PermissionWriteHouseholdList, http.StatusForbidden, h)
+	}
+	mux.HandleFunc("GET /v2/householdlists/", read(s.getLists))
+	mux.HandleFunc("POST /v2/householdlists/", write(s.createList))
+	mux.HandleFunc("GET /v2/householdlists/{listId}/{status}", read(s.getList))
+	mux.HandleFunc("PUT /v2/householdlists/{listId}", write(s.updateList))
+	mux.HandleFunc("DELETE /v2/householdlists/{listId}", write(s.deleteList))
+	mux.HandleFunc("POST /v2/householdlists/{listId}/items", write(s.createListItem))
+	mux.HandleFunc("GET /v2/householdlists/{listId}/items/{itemId}", read(s.getListItem))
+	mux.HandleFunc("PUT /v2/householdlists/{listId}/items/{itemId}", write(s.updateListItem))
+	mux.HandleFunc("DELETE /v2/householdlists/{listId}/items/{itemId}", write(s.deleteListItem))
+}
+
+func (s *Server) getLists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	lists := make([]alexa.ListMetadata, 0, len(s.listOrder))
+	for _, id := range s.listOrder {
+		lists = append(lists, s.lists[id].metadata)
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"lists": lists})
+}
+
+func (s *Server) createList(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	metadata := s.lists[s.addList(req.Name)].metadata
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, metadata)
+}
+
+func (s *Server) getList(w http.ResponseWriter, r *http.Request) {
+	s.withList(w, r, func(l *list) (int, interface{}) {
+		return http.StatusOK, l.list(pathValue(r, "status"))
+	})
+}
+
+func (s *Server) updateList(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name    string `json:"name"`
+		State   string `json:"state"`
+		Version int    `json:"version"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	s.withList(w, r, func(l *list) (int, interface{}) {
+		if req.Version != l.metadata.Version {
+			return http.StatusConflict, conflict()
+		}
+		l.metadata.Name = req.Name
+		l.metadata.State = req.State
+		l.metadata.Version++
+		return http.StatusOK, l.metadata
+	})
+}
+
+func (s *Server) deleteList(w http.ResponseWriter, r *http.Request) {
+	s.withList(w, r, func(l *list) (int, interface{}) {
+		delete(s.lists, l.metadata.ListID)
+		s.listOrder = remove(s.listOrder, l.metadata.ListID)
+		return http.StatusOK, nil
+	})
+}
+
+func (s *Server) createListItem(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Value  string `json:"value"`
+		Status string `json:"status"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	s.withList(w, r, func(l *list) (int, interface{}) {
+		return http.StatusCreated, *s.addListItem(l, req.Value, req.Status)
+	})
+}
+
+func (s *Server) getListItem(w http.ResponseWriter, r *http.Request) {
+	s.withListItem(w, r, func(l *list, item *alexa.ListItem) (int, interface{}) {
+		return http.StatusOK, *item
+	})
+}
+
+func (s *Server) updateListItem(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Value   string `json:"value"`
+		Status  string `json:"status"`
+		Version int    `json:"version"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	s.withListItem(w, r, func(l *list, item *alexa.ListItem) (int, interface{}) {
+		if req.Version != item.Version {
+			return http.StatusConflict, conflict()
+		}
+		item.Value = req.Value
+		item.Status = req.Status
+		item.Version++
+		item.UpdatedTime = time.Now().UTC().Format(alertTimeLayout)
+		return http.StatusOK, *item
+	})
+}
+
+func (s *Server) deleteListItem(w http.ResponseWriter, r *http.Request) {
+	s.withListItem(w, r, func(l *list, item *alexa.ListItem) (int, interface{}) {
+		for i, it := range l.items {
+			if it == item {
+				l.items = append(l.items[:i:i], l.items[i+1:]...)
+				break
+			}
+		}
+		return http.StatusOK, nil
+	})
+}
+
+// withList calls f with the list from the request path while holding s.mu,
+// and writes the status and value it returns.
+func (s *Server) withList(w http.ResponseWriter, r *http.Request, f func(*list) (int, interface{})) {
+	s.mu.Lock()
+	l, ok := s.lists[pathValue(r, "listId")]
+	var status int
+	var v interface{}
+	if ok {
+		status, v = f(l)
+	}
+	s.mu.Unlock()
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The list does not exist.")
+	case v == nil:
+		w.WriteHeader(status)
+	default:
+		writeJSON(w, status, v)
+	}
+}
+
+// withListItem calls f with the list and item from the request path while
+// holding s.mu, and writes the status and value it returns.
+func (s *Server) withListItem(w http.ResponseWriter, r *http.Request, f func(*list, *alexa.ListItem) (int, interface{})) {
+	s.withList(w, r, func(l *list) (int, interface{}) {
+		item := l.item(pathValue(r, "itemId"))
+		if item == nil {
+			return http.StatusNotFound, map[string]string{"code": "NOT_FOUND", "message": "The item does not exist."}
+		}
+		return f(l, item)
+	})
+}
+
+func conflict() map[string]string {
+	return map[string]string{"code": "CONFLICT", "message": "The version does not match the current version."}
+}
@@ -0,0 +1,70 @@
+package fakeservices
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type pathValuesKey struct{}
+
+// router matches requests against patterns such as "GET /v1/alerts/timers/{id}",
+// in which {name} matches a single non-empty path segment. Routes are tried
+// in the order they were added.
+type router struct {
+	routes []route
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+// HandleFunc adds a route for pattern, a method and path separated by a space.
+func (rt *router) HandleFunc(pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	rt.routes = append(rt.routes, route{method: method, segments: splitPath(path), handler: handler})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	for _, route := range rt.routes {
+		if route.method != r.Method {
+			continue
+		}
+		if values, ok := route.match(segments); ok {
+			route.handler(w, r.WithContext(context.WithValue(r.Context(), pathValuesKey{}, values)))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "No endpoint at "+r.Method+" "+r.URL.Path+".")
+}
+
+func (route route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(route.segments) {
+		return nil, false
+	}
+	values := make(map[string]string)
+	for i, s := range route.segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			values[s[1:len(s)-1]] = segments[i]
+		} else if s != segments[i] {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// pathValue returns the path segment matched by {name} in the route pattern.
+func pathValue(r *http.Request, name string) string {
+	values, _ := r.Context().Value(pathValuesKey{}).(map[string]string)
+	return values[name]
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
@@ -0,0 +1,128 @@
+package alexa
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrAttributeNotFound reports that a session attribute was not set.
+var ErrAttributeNotFound = errors.New("attribute not found")
+
+// ErrSessionNil reports that a request was sent outside of a session.
+var ErrSessionNil = errors.New("request does not contain a session")
+
+// Attributes contains the session attributes sent by Alexa. On the wire the
+// attributes are a JSON object of name/value pairs.
+type Attributes struct {
+	// String holds the attribute values keyed by attribute name. Values
+	// decoded from a request use the encoding/json types, so numbers are
+	// float64. Use GetAttr to convert a value to a specific type.
+	String map[string]interface{}
+}
+
+// UnmarshalJSON decodes the attributes object into String.
+func (a *Attributes) UnmarshalJSON(b []byte) error {
+	var m map[string]interface{}
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return err
+	}
+	a.String = m
+	return nil
+}
+
+// MarshalJSON encodes String as the attributes object.
+func (a Attributes) MarshalJSON() ([]byte, error) {
+	if a.String == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a.String)
+}
+
+// GetAttr returns the session attribute name converted to T. Values that are
+// not already a T are converted through their JSON encoding, so a number
+// stored as float64 can be read as an int and an object can be read as a
+// struct. Returns ErrAttributeNotFound if the attribute is not set.
+func GetAttr[T any](s *Session, name string) (T, error) {
+	var t T
+	if s == nil {
+		return t, ErrSessionNil
+	}
+	v, ok := s.Attributes.String[name]
+	if !ok {
+		return t, ErrAttributeNotFound
+	}
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(b, &t)
+	if err != nil {
+		return t, errors.New("unable to convert attribute " + name + ".  Err: " + err.Error())
+	}
+	return t, nil
+}
+
+// SetAttr sets the session attribute name to value. Values are returned to
+// Alexa in the response and sent back in the next request of the session.
+func SetAttr(s *Session, name string, value interface{}) error {
+	if s == nil {
+		return ErrSessionNil
+	}
+	if s.Attributes.String == nil {
+		s.Attributes.String = make(map[string]interface{})
+	}
+	s.Attributes.String[name] = value
+	return nil
+}
+
+// DeleteAttr removes the session attribute name.
+func DeleteAttr(s *Session, name string) error {
+	if s == nil {
+		return ErrSessionNil
+	}
+	delete(s.Attributes.String, name)
+	return nil
+}
+
+// BindAttrs decodes the session attributes into v, which must be a pointer
+// to a struct or map. Struct fields are matched using their json tags.
+func BindAttrs(s *Session, v interface{}) error {
+	if s == nil {
+		return ErrSessionNil
+	}
+	b, err := json.Marshal(s.Attributes.String)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// StoreAttrs encodes v, a struct or map, and sets each of its top level
+// fields as a session attribute. Attributes not present in v are left
+// unchanged.
+func StoreAttrs(s *Session, v interface{}) error {
+	if s == nil {
+		return ErrSessionNil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return errors.New("attributes must encode to a JSON object.  Err: " + err.Error())
+	}
+	if s.Attributes.String == nil {
+		s.Attributes.String = make(map[string]interface{})
+	}
+	for n, v := range m {
+		s.Attributes.String[n] = v
+	}
+	return nil
+}
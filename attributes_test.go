@@ -0,0 +1,112 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+const attributesSessionString = `{
+	"new": false,
+	"sessionId": "amzn1.echo-api.session.[unique-value-here]",
+	"attributes": {
+		"count": 3,
+		"name": "snowball",
+		"recipe": {"title": "Snowball", "servings": 4}
+	},
+	"user": {
+		"userId": "amzn1.ask.account.[unique-value-here]"
+	}
+}`
+
+type testRecipe struct {
+	Title    string `json:"title"`
+	Servings int    `json:"servings"`
+}
+
+func TestAttributesJSON(t *testing.T) {
+	var session Session
+	err := json.Unmarshal([]byte(attributesSessionString), &session)
+	if err != nil {
+		t.Fatal("Error unmarshaling session.", err)
+	}
+	if session.Attributes.String["name"] != "snowball" {
+		t.Error("Expected attribute name to be snowball but was", session.Attributes.String["name"])
+	}
+
+	b, err := json.Marshal(session.Attributes)
+	if err != nil {
+		t.Fatal("Error marshaling attributes.", err)
+	}
+	exp := `{"count":3,"name":"snowball","recipe":{"servings":4,"title":"Snowball"}}`
+	if string(b) != exp {
+		t.Errorf("Expected JSON of "+exp+" but was %s", string(b))
+	}
+}
+
+func TestGetAttr(t *testing.T) {
+	var session Session
+	err := json.Unmarshal([]byte(attributesSessionString), &session)
+	if err != nil {
+		t.Fatal("Error unmarshaling session.", err)
+	}
+
+	count, err := GetAttr[int](&session, "count")
+	if err != nil || count != 3 {
+		t.Errorf("Expected count to be 3 but was %d, err %v", count, err)
+	}
+	name, err := GetAttr[string](&session, "name")
+	if err != nil || name != "snowball" {
+		t.Errorf("Expected name to be snowball but was %s, err %v", name, err)
+	}
+	recipe, err := GetAttr[testRecipe](&session, "recipe")
+	if err != nil || recipe.Servings != 4 {
+		t.Errorf("Expected recipe servings to be 4 but was %d, err %v", recipe.Servings, err)
+	}
+
+	_, err = GetAttr[int](&session, "missing")
+	if err != ErrAttributeNotFound {
+		t.Error("Expected ErrAttributeNotFound but got", err)
+	}
+	_, err = GetAttr[int](&session, "name")
+	if err == nil {
+		t.Error("Expected GetAttr to fail converting a string to an int but no err was returned.")
+	}
+	_, err = GetAttr[int](nil, "count")
+	if err != ErrSessionNil {
+		t.Error("Expected ErrSessionNil but got", err)
+	}
+}
+
+func TestSetAttrAndBinding(t *testing.T) {
+	request := createRecipeRequest()
+
+	alexa := getAlexaWithHandler(&IntentRouter{Fallback: func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		SetAttr(session, "count", 5)
+		return StoreAttrs(session, &testRecipe{Title: "Snowball", Servings: 2})
+	}})
+	responseEnv, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.SessionAttributes["count"] != 5 {
+		t.Error("Session Attribute count should be 5 in ResponseEnvelope but was", responseEnv.SessionAttributes["count"])
+	}
+	if responseEnv.SessionAttributes["title"] != "Snowball" {
+		t.Error("Session Attribute title should be Snowball in ResponseEnvelope but was", responseEnv.SessionAttributes["title"])
+	}
+
+	var recipe testRecipe
+	err = BindAttrs(request.Session, &recipe)
+	if err != nil {
+		t.Fatal("Error binding attributes.", err)
+	}
+	if recipe.Title != "Snowball" || recipe.Servings != 2 {
+		t.Errorf("Expected bound recipe to be Snowball serving 2 but was %s serving %d", recipe.Title, recipe.Servings)
+	}
+
+	DeleteAttr(request.Session, "count")
+	if _, ok := request.Session.Attributes.String["count"]; ok {
+		t.Error("Expected attribute count to be deleted.")
+	}
+}
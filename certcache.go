@@ -0,0 +1,155 @@
+package alexa
+
+import (
+	"container/list"
+	"context"
+	"crypto/x509"
+	"errors"
+	"sync"
+)
+
+// defaultCertCacheSize is the number of signing certificates held by the
+// default CertificateCache of a RequestVerifier.
+const defaultCertCacheSize = 32
+
+// CertificateCache stores validated signing certificates keyed by their
+// SignatureCertChainUrl. Implementations must be safe for concurrent use.
+// RequestVerifier checks the NotAfter of every certificate returned by Get and
+// calls Remove for expired certificates, so implementations are not required
+// to track expiry themselves.
+type CertificateCache interface {
+	Get(certURL string) (*x509.Certificate, bool)
+	Put(certURL string, cert *x509.Certificate)
+	Remove(certURL string)
+}
+
+// LRUCertificateCache is an in-memory CertificateCache that holds up to a
+// fixed number of certificates, evicting the least recently used certificate
+// when full.
+type LRUCertificateCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruCertEntry struct {
+	certURL string
+	cert    *x509.Certificate
+}
+
+// NewLRUCertificateCache creates an LRUCertificateCache holding up to size certificates.
+func NewLRUCertificateCache(size int) *LRUCertificateCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCertificateCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the certificate cached for certURL. Expiry is checked by the
+// RequestVerifier against its Clock.
+func (c *LRUCertificateCache) Get(certURL string) (*x509.Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[certURL]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruCertEntry).cert, true
+}
+
+// Put adds cert to the cache, evicting the least recently used certificate if the cache is full.
+func (c *LRUCertificateCache) Put(certURL string, cert *x509.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[certURL]; ok {
+		e.Value.(*lruCertEntry).cert = cert
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[certURL] = c.order.PushFront(&lruCertEntry{certURL: certURL, cert: cert})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruCertEntry).certURL)
+	}
+}
+
+// Remove deletes the certificate cached for certURL.
+func (c *LRUCertificateCache) Remove(certURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[certURL]; ok {
+		c.order.Remove(e)
+		delete(c.entries, certURL)
+	}
+}
+
+// Len returns the number of certificates in the cache.
+func (c *LRUCertificateCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// certFetchGroup deduplicates concurrent fetches of the same certificate URL.
+type certFetchGroup struct {
+	mu    sync.Mutex
+	calls map[string]*certFetchCall
+
+	// onWait, if set, is called when a caller waits for an in-flight fetch.
+	onWait func()
+}
+
+type certFetchCall struct {
+	done chan struct{}
+	cert *x509.Certificate
+	err  error
+}
+
+// errCertFetchIncomplete is returned to callers waiting for a fetch that
+// panicked.
+var errCertFetchIncomplete = errors.New("signing certificate fetch did not complete")
+
+// do calls fn for certURL, or waits for and returns the result of an
+// in-flight call for the same certURL. A waiting caller returns early with
+// the error of ctx if it is done first.
+func (g *certFetchGroup) do(ctx context.Context, certURL string, fn func() (*x509.Certificate, error)) (*x509.Certificate, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*certFetchCall)
+	}
+	if c, ok := g.calls[certURL]; ok {
+		g.mu.Unlock()
+		if g.onWait != nil {
+			g.onWait()
+		}
+		select {
+		case <-c.done:
+			return c.cert, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := &certFetchCall{done: make(chan struct{}), err: errCertFetchIncomplete}
+	g.calls[certURL] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, certURL)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.cert, c.err = fn()
+	return c.cert, c.err
+}
@@ -0,0 +1,245 @@
+package alexa
+
+import (
+	"context"
+	"crypto/x509"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCertificateCache(t *testing.T) {
+	cache := NewLRUCertificateCache(2)
+	valid := &x509.Certificate{NotAfter: time.Now().Add(time.Hour)}
+
+	cache.Put("a", valid)
+	cache.Put("b", valid)
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected certificate a to be cached.")
+	}
+	cache.Put("c", valid)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected least recently used certificate b to be evicted.")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected cache to contain 2 certificates but contained %d", cache.Len())
+	}
+
+	cache.Remove("c")
+	if _, ok := cache.Get("c"); ok {
+		t.Error("Expected certificate c to be removed.")
+	}
+}
+
+func TestRequestVerifierCachesCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, key := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	fetcher := &countingCertFetcher{data: leafPEM, release: make(chan struct{})}
+	verifier := &RequestVerifier{CertFetcher: fetcher, Roots: ca.pool()}
+	body := []byte(`{"version":"1.0"}`)
+	signature := signBody(t, key, body)
+	ctx := context.Background()
+
+	// Release the fetch once the other 9 requests are waiting for it.
+	var waiting sync.WaitGroup
+	waiting.Add(9)
+	verifier.fetches.onWait = waiting.Done
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- verifier.Verify(ctx, testCertURL, signature, body)
+		}()
+	}
+	waiting.Wait()
+	close(fetcher.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error("Expected Verify to succeed but got error", err)
+		}
+	}
+
+	err := verifier.Verify(ctx, testCertURL, signature, body)
+	if err != nil {
+		t.Error("Expected Verify to succeed but got error", err)
+	}
+	if n := atomic.LoadInt32(&fetcher.count); n != 1 {
+		t.Errorf("Expected certificate chain to be fetched once but was fetched %d times", n)
+	}
+}
+
+func TestRequestVerifierFetchSurvivesCancelledRequest(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, key := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	fetcher := &countingCertFetcher{data: leafPEM, release: make(chan struct{}), started: make(chan struct{})}
+	verifier := &RequestVerifier{CertFetcher: fetcher, Roots: ca.pool()}
+	body := []byte(`{"version":"1.0"}`)
+	signature := signBody(t, key, body)
+
+	waiting := make(chan struct{})
+	verifier.fetches.onWait = func() { close(waiting) }
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go verifier.Verify(leaderCtx, testCertURL, signature, body)
+	<-fetcher.started
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- verifier.Verify(context.Background(), testCertURL, signature, body)
+	}()
+	<-waiting
+	cancel()
+	close(fetcher.release)
+
+	err := <-errs
+	if err != nil {
+		t.Error("Expected the waiting request to succeed after the first request was cancelled but got error", err)
+	}
+}
+
+func TestCertFetchGroupWaiterCancelled(t *testing.T) {
+	var g certFetchGroup
+	waiting := make(chan struct{})
+	g.onWait = func() { close(waiting) }
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go g.do(context.Background(), testCertURL, func() (*x509.Certificate, error) {
+		close(started)
+		<-release
+		return &x509.Certificate{}, nil
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := g.do(ctx, testCertURL, nil)
+		errs <- err
+	}()
+	<-waiting
+	cancel()
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("Expected the waiting caller to return context.Canceled but got %v", err)
+	}
+}
+
+func TestCertFetchGroupPanic(t *testing.T) {
+	var g certFetchGroup
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic to reach the caller.")
+			}
+		}()
+		g.do(context.Background(), testCertURL, func() (*x509.Certificate, error) {
+			panic("fetch failed")
+		})
+	}()
+
+	cert := &x509.Certificate{}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got, err := g.do(ctx, testCertURL, func() (*x509.Certificate, error) { return cert, nil })
+	if err != nil || got != cert {
+		t.Errorf("Expected a new fetch after the panic but got %v, %v", got, err)
+	}
+}
+
+func TestHTTPHandlerSharesDefaultVerifier(t *testing.T) {
+	h := &HTTPHandler{Alexa: getAlexa()}
+	if h.verifier() == nil || h.verifier() != h.verifier() {
+		t.Error("Expected the default RequestVerifier to be created once and reused.")
+	}
+}
+
+func TestRequestVerifierRefetchesExpiredCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, key := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	fetcher := &countingCertFetcher{data: leafPEM}
+	cache := &mapCertificateCache{certs: map[string]*x509.Certificate{
+		testCertURL: {NotAfter: time.Now().Add(-time.Minute)},
+	}}
+	verifier := &RequestVerifier{CertFetcher: fetcher, Roots: ca.pool(), Cache: cache}
+	body := []byte(`{"version":"1.0"}`)
+
+	err := verifier.Verify(context.Background(), testCertURL, signBody(t, key, body), body)
+	if err != nil {
+		t.Error("Expected Verify to succeed but got error", err)
+	}
+	if n := atomic.LoadInt32(&fetcher.count); n != 1 {
+		t.Errorf("Expected expired certificate to be fetched again but was fetched %d times", n)
+	}
+}
+
+func TestRequestVerifierPrewarm(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, _ := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	cache := NewLRUCertificateCache(4)
+	verifier := &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: leafPEM}, Roots: ca.pool(), Cache: cache}
+
+	err := verifier.Prewarm(context.Background(), testCertURL)
+	if err != nil {
+		t.Fatal("Expected Prewarm to succeed but got error", err)
+	}
+	if _, ok := cache.Get(testCertURL); !ok {
+		t.Error("Expected certificate to be cached after Prewarm.")
+	}
+
+	err = verifier.Prewarm(context.Background(), "https://example.com/echo.api/cert.pem")
+	if err == nil {
+		t.Error("Expected Prewarm to fail due to an invalid certificate URL but no err was returned.")
+	}
+}
+
+type countingCertFetcher struct {
+	data    []byte
+	count   int32
+	release chan struct{}
+	// started, if set, is closed when the first fetch starts.
+	started chan struct{}
+}
+
+func (f *countingCertFetcher) FetchCertChain(ctx context.Context, certURL string) ([]byte, error) {
+	if atomic.AddInt32(&f.count, 1) == 1 && f.started != nil {
+		close(f.started)
+	}
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return f.data, nil
+}
+
+type mapCertificateCache struct {
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+func (c *mapCertificateCache) Get(certURL string) (*x509.Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cert, ok := c.certs[certURL]
+	return cert, ok
+}
+
+func (c *mapCertificateCache) Put(certURL string, cert *x509.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.certs[certURL] = cert
+}
+
+func (c *mapCertificateCache) Remove(certURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.certs, certURL)
+}
@@ -0,0 +1,154 @@
+package alexa
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Dialog directive types.
+const (
+	DialogDelegate        = "Dialog.Delegate"
+	DialogElicitSlot      = "Dialog.ElicitSlot"
+	DialogConfirmSlot     = "Dialog.ConfirmSlot"
+	DialogConfirmIntent   = "Dialog.ConfirmIntent"
+	DialogDelegateRequest = "Dialog.DelegateRequest"
+)
+
+// Targets and periods of a Dialog.DelegateRequest directive.
+const (
+	DelegateTargetSkill           = "skill"
+	DelegationUntilExplicitReturn = "EXPLICIT_RETURN"
+)
+
+// ErrInvalidDialogDirective reports that a Dialog directive could not be
+// added because its intent or slot is not valid.
+var ErrInvalidDialogDirective = errors.New("invalid dialog directive")
+
+// DialogDelegateRequestDirective hands the dialog to another target, such as
+// an intent of the skill.
+type DialogDelegateRequestDirective struct {
+	Type           string                 `json:"type"`
+	Target         string                 `json:"target"`
+	Period         DelegationPeriod       `json:"period"`
+	UpdatedRequest DelegatedIntentRequest `json:"updatedRequest"`
+}
+
+// DelegationPeriod sets how long the target of a Dialog.DelegateRequest keeps the dialog.
+type DelegationPeriod struct {
+	Until string `json:"until"`
+}
+
+// DelegatedIntentRequest is the IntentRequest sent to the skill by a
+// Dialog.DelegateRequest directive.
+type DelegatedIntentRequest struct {
+	Type   string `json:"type"`
+	Intent Intent `json:"intent"`
+}
+
+// AddDelegateDirective asks Alexa to choose the next turn of the dialog
+// using the dialog model. updatedIntent may be nil, or may name a different
+// intent to chain to it.
+func (r *Response) AddDelegateDirective(updatedIntent *Intent) error {
+	if updatedIntent != nil && updatedIntent.Name == "" {
+		return fmt.Errorf("%w: updated intent has no name", ErrInvalidDialogDirective)
+	}
+	r.addDialogDirective(DialogDirective{Type: DialogDelegate, UpdatedIntent: updatedIntent})
+	return nil
+}
+
+// AddElicitSlotDirective asks the user for the value of slot, which must be a
+// slot of intent. The response should include the prompt.
+func (r *Response) AddElicitSlotDirective(slot string, intent Intent) error {
+	err := validateDialogSlot(slot, intent)
+	if err != nil {
+		return err
+	}
+	r.addDialogDirective(DialogDirective{Type: DialogElicitSlot, SlotToElicit: slot, UpdatedIntent: &intent})
+	return nil
+}
+
+// AddConfirmSlotDirective asks the user to confirm the value of slot, which
+// must be a slot of intent. The response should include the prompt.
+func (r *Response) AddConfirmSlotDirective(slot string, intent Intent) error {
+	err := validateDialogSlot(slot, intent)
+	if err != nil {
+		return err
+	}
+	r.addDialogDirective(DialogDirective{Type: DialogConfirmSlot, SlotToConfirm: slot, UpdatedIntent: &intent})
+	return nil
+}
+
+// AddConfirmIntentDirective asks the user to confirm all of the information
+// collected for intent. The response should include the prompt.
+func (r *Response) AddConfirmIntentDirective(intent Intent) error {
+	if intent.Name == "" {
+		return fmt.Errorf("%w: intent has no name", ErrInvalidDialogDirective)
+	}
+	r.addDialogDirective(DialogDirective{Type: DialogConfirmIntent, UpdatedIntent: &intent})
+	return nil
+}
+
+// AddDelegateRequestDirective hands the dialog to intent of the skill with a
+// Dialog.DelegateRequest, such as when returning from Alexa Conversations.
+// The skill then receives an IntentRequest for intent.
+func (r *Response) AddDelegateRequestDirective(intent Intent) error {
+	if intent.Name == "" {
+		return fmt.Errorf("%w: intent has no name", ErrInvalidDialogDirective)
+	}
+	if intent.Slots == nil {
+		// Alexa expects an object, so an intent without slots sends {} rather than null.
+		intent.Slots = map[string]IntentSlot{}
+	}
+	r.addDialogDirective(DialogDelegateRequestDirective{
+		Type:           DialogDelegateRequest,
+		Target:         DelegateTargetSkill,
+		Period:         DelegationPeriod{Until: DelegationUntilExplicitReturn},
+		UpdatedRequest: DelegatedIntentRequest{Type: intentRequestName, Intent: intent},
+	})
+	return nil
+}
+
+// addDialogDirective adds d and keeps the session open, as Alexa rejects
+// dialog directives in a response that ends the session.
+func (r *Response) addDialogDirective(d interface{}) {
+	r.Directives = append(r.Directives, d)
+	r.ShouldSessionEnd = false
+}
+
+func validateDialogSlot(slot string, intent Intent) error {
+	if intent.Name == "" {
+		return fmt.Errorf("%w: intent has no name", ErrInvalidDialogDirective)
+	}
+	if _, ok := intent.Slots[slot]; !ok {
+		return fmt.Errorf("%w: intent %s has no slot %s", ErrInvalidDialogDirective, intent.Name, slot)
+	}
+	return nil
+}
+
+// hasDialogDirective reports whether the Response contains a directive that
+// requires the session to stay open.
+func (r *Response) hasDialogDirective() bool {
+	for _, d := range r.Directives {
+		switch d := d.(type) {
+		case DialogDirective:
+			if isOpenSessionDialog(d.Type) {
+				return true
+			}
+		case *DialogDirective:
+			if d != nil && isOpenSessionDialog(d.Type) {
+				return true
+			}
+		case DialogDelegateRequestDirective, *DialogDelegateRequestDirective:
+			return true
+		}
+	}
+	return false
+}
+
+func isOpenSessionDialog(dialogType string) bool {
+	switch dialogType {
+	case DialogDelegate, DialogElicitSlot, DialogConfirmSlot, DialogConfirmIntent, DialogDelegateRequest:
+		return true
+	}
+	return false
+}
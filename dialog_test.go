@@ -0,0 +1,104 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDialogDirectives(t *testing.T) {
+	intent := Intent{Name: "PlanMyTrip", Slots: map[string]IntentSlot{"city": {Name: "city"}}}
+
+	tests := []struct {
+		name string
+		add  func(*Response) error
+		exp  string
+	}{
+		{"Delegate", func(r *Response) error { return r.AddDelegateDirective(nil) },
+			`{"type":"Dialog.Delegate"}`},
+		{"ElicitSlot", func(r *Response) error { return r.AddElicitSlotDirective("city", intent) },
+			`{"type":"Dialog.ElicitSlot","slotToElicit":"city","updatedIntent":{"name":"PlanMyTrip","slots":{"city":{"name":"city","value":""}}}}`},
+		{"ConfirmSlot", func(r *Response) error { return r.AddConfirmSlotDirective("city", intent) },
+			`{"type":"Dialog.ConfirmSlot","slotToConfirm":"city","updatedIntent":{"name":"PlanMyTrip","slots":{"city":{"name":"city","value":""}}}}`},
+		{"ConfirmIntent", func(r *Response) error { return r.AddConfirmIntentDirective(intent) },
+			`{"type":"Dialog.ConfirmIntent","updatedIntent":{"name":"PlanMyTrip","slots":{"city":{"name":"city","value":""}}}}`},
+		{"DelegateRequest", func(r *Response) error { return r.AddDelegateRequestDirective(Intent{Name: "OrderIntent"}) },
+			`{"type":"Dialog.DelegateRequest","target":"skill","period":{"until":"EXPLICIT_RETURN"},"updatedRequest":{"type":"IntentRequest","intent":{"name":"OrderIntent","slots":{}}}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := &Response{ShouldSessionEnd: true}
+			err := test.add(response)
+			if err != nil {
+				t.Fatal("Error adding directive. " + err.Error())
+			}
+			if response.ShouldSessionEnd {
+				t.Error("Expected shouldEndSession to be false.")
+			}
+			b, err := json.Marshal(response.Directives[0])
+			if err != nil {
+				t.Fatal("Error marshaling directive. " + err.Error())
+			}
+			if string(b) != test.exp {
+				t.Errorf("Expected JSON of %s but was %s", test.exp, string(b))
+			}
+		})
+	}
+}
+
+func TestDialogDirectiveValidation(t *testing.T) {
+	intent := Intent{Name: "PlanMyTrip", Slots: map[string]IntentSlot{"city": {Name: "city"}}}
+	response := &Response{}
+
+	errs := []error{
+		response.AddElicitSlotDirective("date", intent),
+		response.AddConfirmSlotDirective("", intent),
+		response.AddElicitSlotDirective("city", Intent{}),
+		response.AddConfirmIntentDirective(Intent{}),
+		response.AddDelegateDirective(&Intent{}),
+		response.AddDelegateRequestDirective(Intent{}),
+	}
+	for i, err := range errs {
+		if !errors.Is(err, ErrInvalidDialogDirective) {
+			t.Errorf("Expected ErrInvalidDialogDirective for case %d but was %v", i, err)
+		}
+	}
+	if len(response.Directives) != 0 {
+		t.Errorf("Expected no directives but was %d", len(response.Directives))
+	}
+}
+
+func TestDialogDirectiveKeepsSessionOpen(t *testing.T) {
+	router := &IntentRouter{}
+	router.HandleIntent("RecipeIntent", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		err := response.AddElicitSlotDirective("Item", request.Intent)
+		response.SetOutputText("Which recipe?")
+		response.ShouldSessionEnd = true
+		return err
+	})
+	alexa := getAlexaWithHandler(router)
+
+	responseEnv, err := alexa.ProcessRequest(context.Background(), createRecipeRequest())
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.ShouldSessionEnd {
+		t.Error("Expected shouldEndSession to be false for a dialog directive.")
+	}
+
+	router = &IntentRouter{}
+	router.HandleIntent("RecipeIntent", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Goodbye")
+		return nil
+	})
+	alexa = getAlexaWithHandler(router)
+
+	responseEnv, err = alexa.ProcessRequest(context.Background(), createRecipeRequest())
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if !responseEnv.Response.ShouldSessionEnd {
+		t.Error("Expected shouldEndSession to remain true without a dialog directive.")
+	}
+}
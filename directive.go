@@ -0,0 +1,41 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+)
+
+// DirectiveClient sends directives to the Alexa Directive Service while a
+// request is being processed, for example to play a progressive response
+// before a slow lookup completes.
+type DirectiveClient struct {
+	client *serviceClient
+}
+
+// NewDirectiveClient creates a DirectiveClient using the API endpoint and
+// access token of the request Context.
+func NewDirectiveClient(c *Context) *DirectiveClient {
+	return NewServiceClientFactory(c).DirectiveClient()
+}
+
+type directiveRequest struct {
+	Header struct {
+		RequestID string `json:"requestId"`
+	} `json:"header"`
+	Directive interface{} `json:"directive"`
+}
+
+type speakDirective struct {
+	Type   string `json:"type"`
+	Speech string `json:"speech"`
+}
+
+// Speak sends a VoicePlayer.Speak progressive response for the request with
+// requestID. The speech may be plain text or SSML wrapped in <speak> tags.
+// Alexa only accepts progressive responses while the request is being
+// processed, so Speak should be called before the RequestHandler returns.
+func (d *DirectiveClient) Speak(ctx context.Context, requestID string, speech string) error {
+	req := directiveRequest{Directive: speakDirective{Type: "VoicePlayer.Speak", Speech: speech}}
+	req.Header.RequestID = requestID
+	return d.client.do(ctx, http.MethodPost, "/v1/directives", req, nil)
+}
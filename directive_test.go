@@ -0,0 +1,107 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newServiceContext(endpoint string) *Context {
+	c := &Context{}
+	c.System.APIEndpoint = endpoint
+	c.System.APIAccessToken = "test-api-token"
+	return c
+}
+
+func TestDirectiveClientSpeak(t *testing.T) {
+	var got struct {
+		Header struct {
+			RequestID string `json:"requestId"`
+		} `json:"header"`
+		Directive struct {
+			Type   string `json:"type"`
+			Speech string `json:"speech"`
+		} `json:"directive"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/directives" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type but was %s", ct)
+		}
+		err := json.NewDecoder(r.Body).Decode(&got)
+		if err != nil {
+			t.Error("Error decoding directive. " + err.Error())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewDirectiveClient(newServiceContext(server.URL)).Speak(context.Background(), "request-1", "<speak>One moment.</speak>")
+	if err != nil {
+		t.Fatal("Error sending directive. " + err.Error())
+	}
+	if got.Header.RequestID != "request-1" {
+		t.Errorf("Expected requestId request-1 but was %s", got.Header.RequestID)
+	}
+	if got.Directive.Type != "VoicePlayer.Speak" || got.Directive.Speech != "<speak>One moment.</speak>" {
+		t.Errorf("Unexpected directive %+v", got.Directive)
+	}
+}
+
+func TestDirectiveClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"INVALID_DIRECTIVE","message":"Invalid requestId"}`))
+	}))
+	defer server.Close()
+
+	err := NewDirectiveClient(newServiceContext(server.URL)).Speak(context.Background(), "request-1", "Hello")
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("Expected a ServiceError but was %v", err)
+	}
+	if serviceErr.StatusCode != http.StatusBadRequest || serviceErr.Code != "INVALID_DIRECTIVE" || serviceErr.Message != "Invalid requestId" {
+		t.Errorf("Unexpected service error %+v", serviceErr)
+	}
+	if err.Error() != "alexa service returned 400 Bad Request INVALID_DIRECTIVE: Invalid requestId" {
+		t.Errorf("Unexpected error message %s", err.Error())
+	}
+}
+
+func TestDirectiveClientCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := NewDirectiveClient(newServiceContext(server.URL)).Speak(ctx, "request-1", "Hello")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled but was %v", err)
+	}
+}
+
+func TestDirectiveClientNoAPIAccess(t *testing.T) {
+	err := NewDirectiveClient(&Context{}).Speak(context.Background(), "request-1", "Hello")
+	if err != ErrNoAPIAccess {
+		t.Errorf("Expected ErrNoAPIAccess but was %v", err)
+	}
+	err = NewDirectiveClient(nil).Speak(context.Background(), "request-1", "Hello")
+	if err != ErrNoAPIAccess {
+		t.Errorf("Expected ErrNoAPIAccess for nil Context but was %v", err)
+	}
+}
@@ -0,0 +1,503 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const dynamoDBTargetPrefix = "DynamoDB_20120810."
+const dynamoDBDefaultPartitionKeyName = "id"
+const dynamoDBDefaultAttributesName = "attributes"
+
+// ErrNoAWSRegion reports that a DynamoDBPersistenceAdapter has no Region to
+// send and sign requests for.
+var ErrNoAWSRegion = errors.New("dynamodb region is not set, set Region or the AWS_REGION environment variable")
+
+// dynamoDBTablePollInterval is the time between checks for a newly created table to become active.
+var dynamoDBTablePollInterval = time.Second
+
+// AWSCredentials are used to sign requests to AWS services.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSCredentialsFromEnv reads credentials from the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables, which
+// are set for AWS Lambda functions.
+func AWSCredentialsFromEnv() AWSCredentials {
+	return AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// DynamoDBError is returned when DynamoDB rejects a request.
+type DynamoDBError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *DynamoDBError) Error() string {
+	return fmt.Sprintf("dynamodb error %d %s: %s", e.StatusCode, e.Type, e.Message)
+}
+
+// DynamoDBPersistenceAdapter is a PersistenceAdapter that stores attributes
+// in a DynamoDB table using the DynamoDB JSON API. Each key is stored as an
+// item with the key in the PartitionKeyName attribute and the attributes as
+// a map in the AttributesName attribute, the same layout used by the other
+// Alexa Skills Kit SDKs.
+type DynamoDBPersistenceAdapter struct {
+	TableName string
+	// PartitionKeyName is the name of the table's partition key. Defaults to "id".
+	PartitionKeyName string
+	// AttributesName is the name of the item attribute holding the attributes. Defaults to "attributes".
+	AttributesName string
+	// CreateTable creates the table on first use if it does not exist.
+	CreateTable bool
+
+	// Region is required, and is used to sign requests even if Endpoint is set.
+	Region      string
+	Credentials AWSCredentials
+	// Endpoint overrides the DynamoDB endpoint, for example to use DynamoDB Local.
+	Endpoint   string
+	HTTPClient *http.Client
+
+	mu           sync.Mutex
+	tableCreated bool
+	// creating is the table creation in progress, if any.
+	creating *dynamoDBTableCreation
+}
+
+// dynamoDBTableCreation is a table creation that other requests wait for.
+type dynamoDBTableCreation struct {
+	done chan struct{}
+	err  error
+}
+
+// NewDynamoDBPersistenceAdapter creates a DynamoDBPersistenceAdapter for
+// tableName using the region from the AWS_REGION environment variable and
+// credentials from AWSCredentialsFromEnv.
+func NewDynamoDBPersistenceAdapter(tableName string) *DynamoDBPersistenceAdapter {
+	return &DynamoDBPersistenceAdapter{
+		TableName:   tableName,
+		Region:      os.Getenv("AWS_REGION"),
+		Credentials: AWSCredentialsFromEnv(),
+	}
+}
+
+// GetAttributes reads the attributes stored under key.
+func (a *DynamoDBPersistenceAdapter) GetAttributes(ctx context.Context, key string) (map[string]interface{}, error) {
+	err := a.ensureTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	in := map[string]interface{}{
+		"TableName":      a.TableName,
+		"Key":            a.itemKey(key),
+		"ConsistentRead": true,
+	}
+	var out struct {
+		Item map[string]json.RawMessage `json:"Item"`
+	}
+	err = a.call(ctx, "GetItem", in, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := out.Item[a.attributesName()]
+	if !ok {
+		return nil, nil
+	}
+	v, err := decodeDynamoDBValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	attributes, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("dynamodb attribute " + a.attributesName() + " is not a map")
+	}
+	return attributes, nil
+}
+
+// SaveAttributes replaces the item stored under key.
+func (a *DynamoDBPersistenceAdapter) SaveAttributes(ctx context.Context, key string, attributes map[string]interface{}) error {
+	err := a.ensureTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	value, err := encodeDynamoDBValue(attributes)
+	if err != nil {
+		return err
+	}
+	item := a.itemKey(key)
+	item[a.attributesName()] = value
+	in := map[string]interface{}{
+		"TableName": a.TableName,
+		"Item":      item,
+	}
+	return a.call(ctx, "PutItem", in, nil)
+}
+
+// DeleteAttributes deletes the item stored under key.
+func (a *DynamoDBPersistenceAdapter) DeleteAttributes(ctx context.Context, key string) error {
+	err := a.ensureTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	in := map[string]interface{}{
+		"TableName": a.TableName,
+		"Key":       a.itemKey(key),
+	}
+	return a.call(ctx, "DeleteItem", in, nil)
+}
+
+func (a *DynamoDBPersistenceAdapter) partitionKeyName() string {
+	if a.PartitionKeyName == "" {
+		return dynamoDBDefaultPartitionKeyName
+	}
+	return a.PartitionKeyName
+}
+
+func (a *DynamoDBPersistenceAdapter) attributesName() string {
+	if a.AttributesName == "" {
+		return dynamoDBDefaultAttributesName
+	}
+	return a.AttributesName
+}
+
+func (a *DynamoDBPersistenceAdapter) itemKey(key string) map[string]interface{} {
+	return map[string]interface{}{a.partitionKeyName(): map[string]string{"S": key}}
+}
+
+// ensureTable creates the table and waits for it to become active if
+// CreateTable is set and the table has not been created by this adapter yet.
+// Concurrent requests wait for a single creation without holding the mutex,
+// and return early if their ctx is done. A failed creation is retried by the
+// next request.
+func (a *DynamoDBPersistenceAdapter) ensureTable(ctx context.Context) error {
+	if !a.CreateTable {
+		return nil
+	}
+	a.mu.Lock()
+	if a.tableCreated {
+		a.mu.Unlock()
+		return nil
+	}
+	if c := a.creating; c != nil {
+		a.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c := &dynamoDBTableCreation{done: make(chan struct{}), err: errors.New("dynamodb table creation did not complete")}
+	a.creating = c
+	a.mu.Unlock()
+
+	defer func() {
+		a.mu.Lock()
+		a.tableCreated = c.err == nil
+		a.creating = nil
+		a.mu.Unlock()
+		close(c.done)
+	}()
+	c.err = a.createTable(ctx)
+	return c.err
+}
+
+// createTable creates the table, ignoring an existing table, and waits for it
+// to become active.
+func (a *DynamoDBPersistenceAdapter) createTable(ctx context.Context) error {
+	in := map[string]interface{}{
+		"TableName": a.TableName,
+		"AttributeDefinitions": []map[string]string{
+			{"AttributeName": a.partitionKeyName(), "AttributeType": "S"},
+		},
+		"KeySchema": []map[string]string{
+			{"AttributeName": a.partitionKeyName(), "KeyType": "HASH"},
+		},
+		"BillingMode": "PAY_PER_REQUEST",
+	}
+	err := a.call(ctx, "CreateTable", in, nil)
+	var dynamoErr *DynamoDBError
+	if err != nil && !(errors.As(err, &dynamoErr) && strings.HasSuffix(dynamoErr.Type, "ResourceInUseException")) {
+		return err
+	}
+
+	for {
+		var out struct {
+			Table struct {
+				TableStatus string `json:"TableStatus"`
+			} `json:"Table"`
+		}
+		err = a.call(ctx, "DescribeTable", map[string]interface{}{"TableName": a.TableName}, &out)
+		if err != nil {
+			return err
+		}
+		if out.Table.TableStatus == "ACTIVE" {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dynamoDBTablePollInterval):
+		}
+	}
+	return nil
+}
+
+// call sends a signed request for the DynamoDB operation and decodes the response into out.
+func (a *DynamoDBPersistenceAdapter) call(ctx context.Context, operation string, in interface{}, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	if a.Region == "" {
+		return ErrNoAWSRegion
+	}
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = "https://dynamodb." + a.Region + ".amazonaws.com"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", dynamoDBTargetPrefix+operation)
+
+	signAWSRequest(req, body, a.Credentials, a.Region, "dynamodb", time.Now())
+
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(b, &e)
+		return &DynamoDBError{StatusCode: resp.StatusCode, Type: e.Type, Message: e.Message}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers to req.
+func signAWSRequest(req *http.Request, body []byte, creds AWSCredentials, region string, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// encodeDynamoDBValue converts a value to a DynamoDB AttributeValue. The value
+// is normalized through its JSON encoding first, so any JSON encodable value
+// can be stored.
+func encodeDynamoDBValue(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var normalized interface{}
+	err = d.Decode(&normalized)
+	if err != nil {
+		return nil, err
+	}
+	return toDynamoDBValue(normalized), nil
+}
+
+func toDynamoDBValue(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case string:
+		return map[string]interface{}{"S": v}
+	case json.Number:
+		return map[string]interface{}{"N": v.String()}
+	case bool:
+		return map[string]interface{}{"BOOL": v}
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = toDynamoDBValue(e)
+		}
+		return map[string]interface{}{"L": l}
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = toDynamoDBValue(e)
+		}
+		return map[string]interface{}{"M": m}
+	default:
+		return map[string]interface{}{"NULL": true}
+	}
+}
+
+// decodeDynamoDBValue converts a DynamoDB AttributeValue to the types used by
+// encoding/json, so numbers are returned as float64.
+func decodeDynamoDBValue(raw json.RawMessage) (interface{}, error) {
+	var av map[string]json.RawMessage
+	err := json.Unmarshal(raw, &av)
+	if err != nil {
+		return nil, err
+	}
+	for t, value := range av {
+		switch t {
+		case "S", "B":
+			var s string
+			err = json.Unmarshal(value, &s)
+			return s, err
+		case "N":
+			var s string
+			err = json.Unmarshal(value, &s)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.ParseFloat(s, 64)
+		case "BOOL":
+			var b bool
+			err = json.Unmarshal(value, &b)
+			return b, err
+		case "NULL":
+			return nil, nil
+		case "SS", "BS":
+			var ss []string
+			err = json.Unmarshal(value, &ss)
+			l := make([]interface{}, len(ss))
+			for i, s := range ss {
+				l[i] = s
+			}
+			return l, err
+		case "NS":
+			var ns []string
+			err = json.Unmarshal(value, &ns)
+			if err != nil {
+				return nil, err
+			}
+			l := make([]interface{}, len(ns))
+			for i, s := range ns {
+				l[i], err = strconv.ParseFloat(s, 64)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return l, nil
+		case "L":
+			var items []json.RawMessage
+			err = json.Unmarshal(value, &items)
+			if err != nil {
+				return nil, err
+			}
+			l := make([]interface{}, len(items))
+			for i, item := range items {
+				l[i], err = decodeDynamoDBValue(item)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return l, nil
+		case "M":
+			var items map[string]json.RawMessage
+			err = json.Unmarshal(value, &items)
+			if err != nil {
+				return nil, err
+			}
+			m := make(map[string]interface{}, len(items))
+			for k, item := range items {
+				m[k], err = decodeDynamoDBValue(item)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return m, nil
+		}
+	}
+	return nil, errors.New("unsupported dynamodb attribute value " + string(raw))
+}
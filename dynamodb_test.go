@@ -0,0 +1,313 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSignAWSRequest(t *testing.T) {
+	// Example request from the AWS Signature Version 4 documentation.
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	now, _ := time.Parse("20060102T150405Z", "20150830T123600Z")
+
+	signAWSRequest(req, nil, creds, "us-east-1", "iam", now)
+
+	exp := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if req.Header.Get("Authorization") != exp {
+		t.Errorf("Expected Authorization of %s but was %s", exp, req.Header.Get("Authorization"))
+	}
+}
+
+func TestDynamoDBPersistenceAdapter(t *testing.T) {
+	fake := newFakeDynamoDB()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	adapter := &DynamoDBPersistenceAdapter{
+		TableName:   "skill-attributes",
+		CreateTable: true,
+		Region:      "us-east-1",
+		Credentials: AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"},
+		Endpoint:    server.URL,
+	}
+	ctx := context.Background()
+	key := "amzn1.ask.account.[unique-value-here]"
+
+	attributes, err := adapter.GetAttributes(ctx, key)
+	if err != nil || attributes != nil {
+		t.Fatalf("Expected no attributes for a new key but got %v, err %v", attributes, err)
+	}
+	if _, ok := fake.tables["skill-attributes"]; !ok {
+		t.Error("Expected the table to be created.")
+	}
+
+	err = adapter.SaveAttributes(ctx, key, map[string]interface{}{
+		"visits":   3,
+		"name":     "snowball",
+		"premium":  true,
+		"history":  []string{"a", "b"},
+		"settings": map[string]interface{}{"volume": 0.5, "last": nil},
+	})
+	if err != nil {
+		t.Fatal("Error saving attributes.", err)
+	}
+	attributes, err = adapter.GetAttributes(ctx, key)
+	if err != nil {
+		t.Fatal("Error getting attributes.", err)
+	}
+	if attributes["visits"] != float64(3) || attributes["name"] != "snowball" || attributes["premium"] != true {
+		t.Errorf("Attributes were not stored correctly: %v", attributes)
+	}
+	if history, ok := attributes["history"].([]interface{}); !ok || len(history) != 2 || history[1] != "b" {
+		t.Errorf("Expected history to be [a b] but was %v", attributes["history"])
+	}
+	if settings, ok := attributes["settings"].(map[string]interface{}); !ok || settings["volume"] != 0.5 {
+		t.Errorf("Expected settings.volume to be 0.5 but was %v", attributes["settings"])
+	}
+
+	var item map[string]json.RawMessage
+	json.Unmarshal(fake.items[key], &item)
+	if _, ok := item["id"]; !ok {
+		t.Error("Expected the item to use the default partition key name id.")
+	}
+
+	err = adapter.DeleteAttributes(ctx, key)
+	if err != nil {
+		t.Fatal("Error deleting attributes.", err)
+	}
+	attributes, err = adapter.GetAttributes(ctx, key)
+	if err != nil || attributes != nil {
+		t.Errorf("Expected no attributes after delete but got %v, err %v", attributes, err)
+	}
+}
+
+func TestDynamoDBPersistenceAdapterCustomNames(t *testing.T) {
+	fake := newFakeDynamoDB()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	adapter := &DynamoDBPersistenceAdapter{
+		TableName:        "skill-attributes",
+		PartitionKeyName: "userId",
+		AttributesName:   "data",
+		CreateTable:      true,
+		Region:           "us-east-1",
+		Credentials:      AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"},
+		Endpoint:         server.URL,
+	}
+	ctx := context.Background()
+
+	err := adapter.SaveAttributes(ctx, "user-1", map[string]interface{}{"visits": 1})
+	if err != nil {
+		t.Fatal("Error saving attributes.", err)
+	}
+	if fake.tables["skill-attributes"] != "userId" {
+		t.Errorf("Expected the table to be created with partition key userId but was %s", fake.tables["skill-attributes"])
+	}
+
+	var item map[string]json.RawMessage
+	json.Unmarshal(fake.items["user-1"], &item)
+	if _, ok := item["userId"]; !ok {
+		t.Errorf("Expected the item to use the partition key userId but was %s", fake.items["user-1"])
+	}
+	if _, ok := item["data"]; !ok {
+		t.Errorf("Expected the item to store attributes in data but was %s", fake.items["user-1"])
+	}
+
+	attributes, err := adapter.GetAttributes(ctx, "user-1")
+	if err != nil || attributes["visits"] != float64(1) {
+		t.Errorf("Expected visits to be 1 but got %v, err %v", attributes, err)
+	}
+	err = adapter.DeleteAttributes(ctx, "user-1")
+	if err != nil || len(fake.items) != 0 {
+		t.Errorf("Expected the item to be deleted but got %v, err %v", fake.items, err)
+	}
+}
+
+func TestDynamoDBPersistenceAdapterNoRegion(t *testing.T) {
+	adapter := &DynamoDBPersistenceAdapter{TableName: "skill-attributes"}
+	ctx := context.Background()
+
+	endpointOnly := &DynamoDBPersistenceAdapter{TableName: "skill-attributes", Endpoint: "http://127.0.0.1:0"}
+	_, err := endpointOnly.GetAttributes(ctx, "key")
+	if !errors.Is(err, ErrNoAWSRegion) {
+		t.Errorf("Expected ErrNoAWSRegion with only an Endpoint but got %v", err)
+	}
+
+	_, err = adapter.GetAttributes(ctx, "key")
+	if !errors.Is(err, ErrNoAWSRegion) {
+		t.Errorf("Expected ErrNoAWSRegion from GetAttributes but got %v", err)
+	}
+	err = adapter.SaveAttributes(ctx, "key", map[string]interface{}{})
+	if !errors.Is(err, ErrNoAWSRegion) {
+		t.Errorf("Expected ErrNoAWSRegion from SaveAttributes but got %v", err)
+	}
+	err = adapter.DeleteAttributes(ctx, "key")
+	if !errors.Is(err, ErrNoAWSRegion) {
+		t.Errorf("Expected ErrNoAWSRegion from DeleteAttributes but got %v", err)
+	}
+}
+
+func TestDynamoDBPersistenceAdapterWaitsForTable(t *testing.T) {
+	fake := newFakeDynamoDB()
+	fake.tableStatus = "CREATING"
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	interval := dynamoDBTablePollInterval
+	dynamoDBTablePollInterval = 10 * time.Millisecond
+	defer func() { dynamoDBTablePollInterval = interval }()
+
+	adapter := &DynamoDBPersistenceAdapter{
+		TableName:   "skill-attributes",
+		CreateTable: true,
+		Region:      "us-east-1",
+		Credentials: AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"},
+		Endpoint:    server.URL,
+	}
+	errs := make(chan error, 1)
+	go func() {
+		_, err := adapter.GetAttributes(context.Background(), "key")
+		errs <- err
+	}()
+	for {
+		fake.mu.Lock()
+		_, created := fake.tables["skill-attributes"]
+		fake.mu.Unlock()
+		if created {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := adapter.GetAttributes(ctx, "key")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a request waiting for the table to return at its deadline but got %v", err)
+	}
+
+	fake.mu.Lock()
+	fake.tableStatus = "ACTIVE"
+	fake.mu.Unlock()
+	if err := <-errs; err != nil {
+		t.Error("Expected the request creating the table to succeed but got", err)
+	}
+	_, err = adapter.GetAttributes(context.Background(), "key")
+	if err != nil {
+		t.Error("Expected a request after the table became active to succeed but got", err)
+	}
+}
+
+func TestDynamoDBPersistenceAdapterErrors(t *testing.T) {
+	fake := newFakeDynamoDB()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	adapter := &DynamoDBPersistenceAdapter{
+		TableName:        "missing",
+		PartitionKeyName: "userId",
+		Region:           "us-east-1",
+		Credentials:      AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"},
+		Endpoint:         server.URL,
+	}
+	_, err := adapter.GetAttributes(context.Background(), "key")
+	var dynamoErr *DynamoDBError
+	if !errors.As(err, &dynamoErr) || !strings.HasSuffix(dynamoErr.Type, "ResourceNotFoundException") {
+		t.Error("Expected a ResourceNotFoundException DynamoDBError but got", err)
+	}
+}
+
+// fakeDynamoDB implements the subset of the DynamoDB JSON API used by
+// DynamoDBPersistenceAdapter. Items are keyed by the partition key from the
+// key schema of their table.
+type fakeDynamoDB struct {
+	mu sync.Mutex
+	// tables maps each table to the name of its partition key.
+	tables map[string]string
+	items  map[string][]byte
+	// tableStatus is returned by DescribeTable.
+	tableStatus string
+}
+
+func newFakeDynamoDB() *fakeDynamoDB {
+	return &fakeDynamoDB{tables: make(map[string]string), items: make(map[string][]byte), tableStatus: "ACTIVE"}
+}
+
+func (f *fakeDynamoDB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		f.fail(w, http.StatusForbidden, "MissingAuthenticationTokenException")
+		return
+	}
+
+	var in struct {
+		TableName string
+		Key       map[string]map[string]string
+		Item      map[string]json.RawMessage
+		KeySchema []struct {
+			AttributeName string
+		}
+	}
+	json.NewDecoder(r.Body).Decode(&in)
+
+	operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), dynamoDBTargetPrefix)
+	if operation == "CreateTable" {
+		f.tables[in.TableName] = in.KeySchema[0].AttributeName
+		w.Write([]byte(`{}`))
+		return
+	}
+	keyName, ok := f.tables[in.TableName]
+	if !ok {
+		f.fail(w, http.StatusBadRequest, "com.amazonaws.dynamodb.v20120810#ResourceNotFoundException")
+		return
+	}
+
+	if operation == "PutItem" {
+		var key map[string]string
+		json.Unmarshal(in.Item[keyName], &key)
+		in.Key = map[string]map[string]string{keyName: key}
+	}
+	if operation != "DescribeTable" && (len(in.Key) != 1 || in.Key[keyName]["S"] == "") {
+		f.fail(w, http.StatusBadRequest, "com.amazonaws.dynamodb.v20120810#ValidationException")
+		return
+	}
+	key := in.Key[keyName]["S"]
+
+	switch operation {
+	case "DescribeTable":
+		w.Write([]byte(`{"Table":{"TableStatus":"` + f.tableStatus + `"}}`))
+	case "GetItem":
+		item, ok := f.items[key]
+		if !ok {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`{"Item":` + string(item) + `}`))
+	case "PutItem":
+		b, _ := json.Marshal(in.Item)
+		f.items[key] = b
+		w.Write([]byte(`{}`))
+	case "DeleteItem":
+		delete(f.items, key)
+		w.Write([]byte(`{}`))
+	default:
+		f.fail(w, http.StatusBadRequest, "UnknownOperationException")
+	}
+}
+
+func (f *fakeDynamoDB) fail(w http.ResponseWriter, status int, errType string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"__type": errType, "message": errType})
+}
@@ -0,0 +1,202 @@
+package alexa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DialogUpdateDynamicEntities is the type of the directive that changes the
+// values of slot types for the rest of the session.
+const DialogUpdateDynamicEntities = "Dialog.UpdateDynamicEntities"
+
+// Update behaviors of a Dialog.UpdateDynamicEntities directive.
+const (
+	DynamicEntitiesReplace = "REPLACE"
+	DynamicEntitiesClear   = "CLEAR"
+)
+
+// Limits Amazon places on a Dialog.UpdateDynamicEntities directive.
+const (
+	// MaxDynamicEntities is the number of values allowed across all slot types.
+	MaxDynamicEntities = 100
+	// MaxDynamicEntityLength is the length allowed for a value or synonym.
+	MaxDynamicEntityLength = 140
+)
+
+// Entity resolution status codes reported in Resolutions.
+const (
+	ResolutionSuccessMatch   = "ER_SUCCESS_MATCH"
+	ResolutionSuccessNoMatch = "ER_SUCCESS_NO_MATCH"
+	ResolutionErrorTimeout   = "ER_ERROR_TIMEOUT"
+	ResolutionErrorException = "ER_ERROR_EXCEPTION"
+)
+
+// ErrInvalidDynamicEntities reports that a DynamicEntitiesBuilder could not
+// build a valid directive.
+var ErrInvalidDynamicEntities = errors.New("invalid dynamic entities")
+
+// DynamicEntitiesDirective is a Dialog.UpdateDynamicEntities directive.
+type DynamicEntitiesDirective struct {
+	Type           string     `json:"type"`
+	UpdateBehavior string     `json:"updateBehavior"`
+	Types          []SlotType `json:"types,omitempty"`
+}
+
+// SlotType contains the dynamic values of a slot type.
+type SlotType struct {
+	Name   string          `json:"name"`
+	Values []SlotTypeValue `json:"values"`
+}
+
+// SlotTypeValue is a slot value with an optional ID and synonyms.
+type SlotTypeValue struct {
+	ID   string            `json:"id,omitempty"`
+	Name SlotTypeValueName `json:"name"`
+}
+
+// SlotTypeValueName contains the value and synonyms of a SlotTypeValue.
+type SlotTypeValueName struct {
+	Value    string   `json:"value"`
+	Synonyms []string `json:"synonyms,omitempty"`
+}
+
+// DynamicEntitiesBuilder builds a Dialog.UpdateDynamicEntities directive
+// replacing the dynamic values of one or more slot types.
+type DynamicEntitiesBuilder struct {
+	types []SlotType
+}
+
+// NewDynamicEntities creates a DynamicEntitiesBuilder.
+func NewDynamicEntities() *DynamicEntitiesBuilder {
+	return &DynamicEntitiesBuilder{}
+}
+
+// Value adds a value to slotType. The id is returned in the resolutions of
+// slots matching the value or one of its synonyms.
+func (b *DynamicEntitiesBuilder) Value(slotType, id, value string, synonyms ...string) *DynamicEntitiesBuilder {
+	v := SlotTypeValue{ID: id, Name: SlotTypeValueName{Value: value, Synonyms: synonyms}}
+	for i := range b.types {
+		if b.types[i].Name == slotType {
+			b.types[i].Values = append(b.types[i].Values, v)
+			return b
+		}
+	}
+	b.types = append(b.types, SlotType{Name: slotType, Values: []SlotTypeValue{v}})
+	return b
+}
+
+// Build validates the values against the limits Amazon places on the
+// directive, and that the IDs within a slot type are unique, and returns a
+// directive with the REPLACE behavior.
+func (b *DynamicEntitiesBuilder) Build() (*DynamicEntitiesDirective, error) {
+	if len(b.types) == 0 {
+		return nil, fmt.Errorf("%w: no values were added", ErrInvalidDynamicEntities)
+	}
+	count := 0
+	for _, t := range b.types {
+		if t.Name == "" {
+			return nil, fmt.Errorf("%w: slot type has no name", ErrInvalidDynamicEntities)
+		}
+		ids := make(map[string]bool)
+		for _, v := range t.Values {
+			count++
+			if v.Name.Value == "" {
+				return nil, fmt.Errorf("%w: slot type %s has an empty value", ErrInvalidDynamicEntities, t.Name)
+			}
+			if v.ID != "" {
+				if ids[v.ID] {
+					return nil, fmt.Errorf("%w: slot type %s has more than one value with ID %s", ErrInvalidDynamicEntities, t.Name, v.ID)
+				}
+				ids[v.ID] = true
+			}
+			for _, s := range append([]string{v.Name.Value}, v.Name.Synonyms...) {
+				if utf8.RuneCountInString(s) > MaxDynamicEntityLength {
+					return nil, fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidDynamicEntities, s, MaxDynamicEntityLength)
+				}
+			}
+		}
+	}
+	if count > MaxDynamicEntities {
+		return nil, fmt.Errorf("%w: %d values exceed the limit of %d", ErrInvalidDynamicEntities, count, MaxDynamicEntities)
+	}
+
+	types := make([]SlotType, len(b.types))
+	for i, t := range b.types {
+		types[i] = SlotType{Name: t.Name, Values: append([]SlotTypeValue(nil), t.Values...)}
+	}
+	return &DynamicEntitiesDirective{Type: DialogUpdateDynamicEntities, UpdateBehavior: DynamicEntitiesReplace, Types: types}, nil
+}
+
+// AddDynamicEntitiesDirective adds a Dialog.UpdateDynamicEntities directive
+// created with a DynamicEntitiesBuilder to the Response.
+func (r *Response) AddDynamicEntitiesDirective(d *DynamicEntitiesDirective) {
+	r.Directives = append(r.Directives, *d)
+}
+
+// AddClearDynamicEntitiesDirective removes all dynamic values of the skill
+// for the rest of the session.
+func (r *Response) AddClearDynamicEntitiesDirective() {
+	r.Directives = append(r.Directives, DynamicEntitiesDirective{Type: DialogUpdateDynamicEntities, UpdateBehavior: DynamicEntitiesClear})
+}
+
+// ResolvedValue is a slot value matched by entity resolution.
+type ResolvedValue struct {
+	Authority string
+	Name      string
+	ID        string
+	// Dynamic is true if the value was matched from dynamic entities rather
+	// than the slot type of the interaction model.
+	Dynamic bool
+}
+
+// Matches returns the values of the authorities that matched the slot, in
+// the order Alexa reported them.
+func (r *Resolutions) Matches() []ResolvedValue {
+	if r == nil {
+		return nil
+	}
+	var matches []ResolvedValue
+	for _, authority := range r.ResolutionsPerAuthority {
+		if authority.Status.Code != ResolutionSuccessMatch {
+			continue
+		}
+		for _, v := range authority.Values {
+			matches = append(matches, ResolvedValue{
+				Authority: authority.Authority,
+				Name:      v.Value.Name,
+				ID:        v.Value.ID,
+				Dynamic:   isDynamicAuthority(authority.Authority),
+			})
+		}
+	}
+	return matches
+}
+
+// DynamicMatch returns the first value matched from dynamic entities.
+func (r *Resolutions) DynamicMatch() (ResolvedValue, bool) {
+	return r.firstMatch(true)
+}
+
+// StaticMatch returns the first value matched from the slot type of the
+// interaction model.
+func (r *Resolutions) StaticMatch() (ResolvedValue, bool) {
+	return r.firstMatch(false)
+}
+
+func (r *Resolutions) firstMatch(dynamic bool) (ResolvedValue, bool) {
+	for _, m := range r.Matches() {
+		if m.Dynamic == dynamic {
+			return m, true
+		}
+	}
+	return ResolvedValue{}, false
+}
+
+// isDynamicAuthority reports whether authority, such as
+// "amzn1.er-authority.echo-sdk.dynamic.<skill-id>.AirportSlotType", is the
+// authority of dynamic entities.
+func isDynamicAuthority(authority string) bool {
+	return strings.Contains(authority, ".dynamic.")
+}
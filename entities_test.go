@@ -0,0 +1,130 @@
+package alexa
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDynamicEntitiesBuilder(t *testing.T) {
+	d, err := NewDynamicEntities().
+		Value("AirportSlotType", "BOS", "Logan International Airport", "Boston Logan").
+		Value("PlaylistSlotType", "p1", "Road Trip").
+		Value("AirportSlotType", "LGA", "LaGuardia Airport").
+		Build()
+	if err != nil {
+		t.Fatal("Error building dynamic entities. " + err.Error())
+	}
+
+	response := &Response{}
+	response.AddDynamicEntitiesDirective(d)
+	response.AddClearDynamicEntitiesDirective()
+	for _, directive := range response.Directives {
+		if _, ok := directive.(DynamicEntitiesDirective); !ok {
+			t.Errorf("Expected a DynamicEntitiesDirective value but was %T", directive)
+		}
+	}
+	b, err := json.Marshal(response.Directives[0])
+	if err != nil {
+		t.Fatal("Error marshaling directive. " + err.Error())
+	}
+	exp := `{"type":"Dialog.UpdateDynamicEntities","updateBehavior":"REPLACE","types":[` +
+		`{"name":"AirportSlotType","values":[{"id":"BOS","name":{"value":"Logan International Airport","synonyms":["Boston Logan"]}},{"id":"LGA","name":{"value":"LaGuardia Airport"}}]},` +
+		`{"name":"PlaylistSlotType","values":[{"id":"p1","name":{"value":"Road Trip"}}]}]}`
+	if string(b) != exp {
+		t.Errorf("Expected JSON of %s but was %s", exp, string(b))
+	}
+}
+
+func TestClearDynamicEntities(t *testing.T) {
+	response := &Response{}
+	response.AddClearDynamicEntitiesDirective()
+	b, err := json.Marshal(response.Directives[0])
+	if err != nil {
+		t.Fatal("Error marshaling directive. " + err.Error())
+	}
+	exp := `{"type":"Dialog.UpdateDynamicEntities","updateBehavior":"CLEAR"}`
+	if string(b) != exp {
+		t.Errorf("Expected JSON of %s but was %s", exp, string(b))
+	}
+}
+
+func TestDynamicEntitiesValidation(t *testing.T) {
+	tooMany := NewDynamicEntities()
+	for i := 0; i <= MaxDynamicEntities; i++ {
+		tooMany.Value("PlaylistSlotType", "", "playlist "+strings.Repeat("a", i%10))
+	}
+
+	tests := map[string]*DynamicEntitiesBuilder{
+		"empty":        NewDynamicEntities(),
+		"no type name": NewDynamicEntities().Value("", "id", "value"),
+		"empty value":  NewDynamicEntities().Value("PlaylistSlotType", "id", ""),
+		"long value":   NewDynamicEntities().Value("PlaylistSlotType", "id", strings.Repeat("a", MaxDynamicEntityLength+1)),
+		"long synonym": NewDynamicEntities().Value("PlaylistSlotType", "id", "value", strings.Repeat("a", MaxDynamicEntityLength+1)),
+		"too many":     tooMany,
+		"duplicate id": NewDynamicEntities().Value("PlaylistSlotType", "p1", "Road Trip").Value("PlaylistSlotType", "p1", "Workout"),
+	}
+	for name, b := range tests {
+		_, err := b.Build()
+		if !errors.Is(err, ErrInvalidDynamicEntities) {
+			t.Errorf("Expected ErrInvalidDynamicEntities for %s but was %v", name, err)
+		}
+	}
+
+	_, err := NewDynamicEntities().
+		Value("PlaylistSlotType", "id", strings.Repeat("é", MaxDynamicEntityLength)).
+		Value("AirportSlotType", "id", "Logan").
+		Value("AirportSlotType", "", "LaGuardia").
+		Value("AirportSlotType", "", "Newark").
+		Build()
+	if err != nil {
+		t.Errorf("Expected values of %d characters, and IDs repeated only across slot types or empty, to be valid but was %v", MaxDynamicEntityLength, err)
+	}
+}
+
+func TestResolutionsMatches(t *testing.T) {
+	var slot IntentSlot
+	err := json.Unmarshal([]byte(`{
+		"name": "airport",
+		"value": "logan",
+		"resolutions": {
+			"resolutionsPerAuthority": [
+				{
+					"authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.AirportSlotType",
+					"status": {"code": "ER_SUCCESS_MATCH"},
+					"values": [{"value": {"name": "Boston Logan International", "id": "KBOS"}}]
+				},
+				{
+					"authority": "amzn1.er-authority.echo-sdk.dynamic.amzn1.ask.skill.1234.AirportSlotType",
+					"status": {"code": "ER_SUCCESS_MATCH"},
+					"values": [{"value": {"name": "Logan International Airport", "id": "BOS"}}]
+				}
+			]
+		}
+	}`), &slot)
+	if err != nil {
+		t.Fatal("Error decoding slot. " + err.Error())
+	}
+
+	matches := slot.Resolutions.Matches()
+	if len(matches) != 2 || matches[0].Dynamic || !matches[1].Dynamic {
+		t.Errorf("Unexpected matches %+v", matches)
+	}
+	if m, ok := slot.Resolutions.DynamicMatch(); !ok || m.ID != "BOS" {
+		t.Errorf("Expected the dynamic match BOS but was %+v", m)
+	}
+	if m, ok := slot.Resolutions.StaticMatch(); !ok || m.ID != "KBOS" {
+		t.Errorf("Expected the static match KBOS but was %+v", m)
+	}
+
+	slot.Resolutions.ResolutionsPerAuthority[1].Status.Code = ResolutionSuccessNoMatch
+	if m, ok := slot.Resolutions.DynamicMatch(); ok {
+		t.Errorf("Expected no dynamic match but was %+v", m)
+	}
+
+	var none *Resolutions
+	if matches := none.Matches(); matches != nil {
+		t.Errorf("Expected no matches for nil Resolutions but was %+v", matches)
+	}
+}
@@ -0,0 +1,59 @@
+package alexa
+
+import (
+	"context"
+)
+
+// RequestInterceptor is called before the request is dispatched to the
+// RequestHandler. Returning true skips the RequestHandler and returns the
+// Response as filled in by the interceptor. Returning an error stops
+// processing and passes the error to the ErrorInterceptors.
+type RequestInterceptor func(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error)
+
+// ResponseInterceptor is called after the RequestHandler has filled in the
+// Response and the session attributes have been copied into the
+// ResponseEnvelope. Returning an error passes it to the ErrorInterceptors.
+type ResponseInterceptor func(ctx context.Context, requestEnv *RequestEnvelope, responseEnv *ResponseEnvelope) error
+
+// ErrorInterceptor is called when an interceptor or the RequestHandler
+// returns an error. Returning nil marks the error as handled and the Response
+// is returned to Alexa. Returning an error passes it to the next
+// ErrorInterceptor, and the error returned by the last one is returned from
+// ProcessRequest.
+type ErrorInterceptor func(ctx context.Context, requestEnv *RequestEnvelope, response *Response, err error) error
+
+// interceptRequest calls the RequestInterceptors in order, stopping at the
+// first one that handles the request or returns an error.
+func (alexa *Alexa) interceptRequest(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error) {
+	for _, interceptor := range alexa.RequestInterceptors {
+		handled, err := interceptor(ctx, requestEnv, response)
+		if err != nil || handled {
+			return handled, err
+		}
+	}
+	return false, nil
+}
+
+// interceptResponse calls the ResponseInterceptors in order, stopping at the
+// first one that returns an error.
+func (alexa *Alexa) interceptResponse(ctx context.Context, requestEnv *RequestEnvelope, responseEnv *ResponseEnvelope) error {
+	for _, interceptor := range alexa.ResponseInterceptors {
+		err := interceptor(ctx, requestEnv, responseEnv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interceptError passes err through the ErrorInterceptors, returning nil if
+// one of them handled it.
+func (alexa *Alexa) interceptError(ctx context.Context, requestEnv *RequestEnvelope, response *Response, err error) error {
+	for _, interceptor := range alexa.ErrorInterceptors {
+		err = interceptor(ctx, requestEnv, response, err)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequestInterceptorShortCircuits(t *testing.T) {
+	request := createRecipeRequest()
+
+	handler := &emptyRequestHandler{}
+	alexa := getAlexaWithHandler(handler)
+	var calls []string
+	alexa.RequestInterceptors = []RequestInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error) {
+			calls = append(calls, "first")
+			return false, nil
+		},
+		func(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error) {
+			calls = append(calls, "second")
+			response.SetOutputText("Not authorized")
+			return true, nil
+		},
+		func(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error) {
+			calls = append(calls, "third")
+			return false, nil
+		},
+	}
+	alexa.ResponseInterceptors = []ResponseInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, responseEnv *ResponseEnvelope) error {
+			calls = append(calls, "response")
+			return nil
+		},
+	}
+
+	responseEnv, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if handler.OnIntentCalled {
+		t.Error("OnIntent was called after a RequestInterceptor handled the request.")
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Not authorized" {
+		t.Errorf("Response Text should have been 'Not authorized' but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+	if len(calls) != 3 || calls[0] != "first" || calls[1] != "second" || calls[2] != "response" {
+		t.Errorf("Interceptors were called in an unexpected order: %v", calls)
+	}
+}
+
+func TestResponseInterceptorSeesResponse(t *testing.T) {
+	request := createRecipeRequest()
+
+	handler := &emptyRequestHandler{OnIntentSetsSessionAttr: true}
+	alexa := getAlexaWithHandler(handler)
+	alexa.ResponseInterceptors = []ResponseInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, responseEnv *ResponseEnvelope) error {
+			if responseEnv.SessionAttributes["myNewAttr"] != "Set123" {
+				t.Error("Session Attribute myNewAttr should be visible to the ResponseInterceptor.")
+			}
+			responseEnv.Response.SetOutputText("Intercepted")
+			return nil
+		},
+	}
+
+	responseEnv, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if !handler.OnIntentCalled {
+		t.Error("OnIntent was not called.")
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Intercepted" {
+		t.Errorf("Response Text should have been Intercepted but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+}
+
+func TestErrorInterceptor(t *testing.T) {
+	request := createRecipeRequest()
+
+	handler := &emptyRequestHandler{OnIntentThrowsErr: true}
+	alexa := getAlexaWithHandler(handler)
+	var seen error
+	alexa.ErrorInterceptors = []ErrorInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, response *Response, err error) error {
+			seen = err
+			response.SetOutputText("Sorry, something went wrong.")
+			return nil
+		},
+	}
+
+	responseEnv, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Expected ErrorInterceptor to handle the error but got", err)
+	}
+	if seen == nil || seen.Error() != "error in OnIntent" {
+		t.Error("ErrorInterceptor should have received the OnIntent error but got", seen)
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Sorry, something went wrong." {
+		t.Errorf("Response Text should have been set by the ErrorInterceptor but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+
+	errResponse := errors.New("response interceptor failed")
+	alexa.ErrorInterceptors = []ErrorInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, response *Response, err error) error {
+			return err
+		},
+	}
+	handler.OnIntentThrowsErr = false
+	alexa.ResponseInterceptors = []ResponseInterceptor{
+		func(ctx context.Context, requestEnv *RequestEnvelope, responseEnv *ResponseEnvelope) error {
+			return errResponse
+		},
+	}
+	_, err = alexa.ProcessRequest(context.Background(), request)
+	if err != errResponse {
+		t.Error("Expected ProcessRequest to return the unhandled ResponseInterceptor error but got", err)
+	}
+}
@@ -0,0 +1,232 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Permissions requested with SetAskForPermissionsConsentCard to read and
+// write household lists.
+const (
+	PermissionReadHouseholdList  = "read::alexa:household:list"
+	PermissionWriteHouseholdList = "write::alexa:household:list"
+)
+
+// List and list item states.
+const (
+	ListStateActive   = "active"
+	ListStateArchived = "archived"
+	ListItemActive    = "active"
+	ListItemCompleted = "completed"
+)
+
+const householdListsPath = "/v2/householdlists/"
+
+// Household list event request types.
+const (
+	HouseholdListEventListCreated  = "AlexaHouseholdListEvent.ListCreated"
+	HouseholdListEventListUpdated  = "AlexaHouseholdListEvent.ListUpdated"
+	HouseholdListEventListDeleted  = "AlexaHouseholdListEvent.ListDeleted"
+	HouseholdListEventItemsCreated = "AlexaHouseholdListEvent.ItemsCreated"
+	HouseholdListEventItemsUpdated = "AlexaHouseholdListEvent.ItemsUpdated"
+	HouseholdListEventItemsDeleted = "AlexaHouseholdListEvent.ItemsDeleted"
+)
+
+// ListStatus links to the items of a list with a status.
+type ListStatus struct {
+	Href   string `json:"href"`
+	Status string `json:"status"`
+}
+
+// ListMetadata describes a household list.
+type ListMetadata struct {
+	ListID    string       `json:"listId"`
+	Name      string       `json:"name"`
+	State     string       `json:"state"`
+	Version   int          `json:"version"`
+	StatusMap []ListStatus `json:"statusMap"`
+}
+
+// List is a household list with the items of one status.
+type List struct {
+	ListID  string     `json:"listId"`
+	Name    string     `json:"name"`
+	State   string     `json:"state"`
+	Version int        `json:"version"`
+	Items   []ListItem `json:"items"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// ListItem is an item of a household list.
+type ListItem struct {
+	ID          string `json:"id"`
+	Version     int    `json:"version"`
+	Value       string `json:"value"`
+	Status      string `json:"status"`
+	CreatedTime string `json:"createdTime"`
+	UpdatedTime string `json:"updatedTime"`
+	Href        string `json:"href"`
+}
+
+// ListsClient reads and modifies the user's household lists, such as the
+// Alexa shopping and to-do lists, with the List Management API.
+type ListsClient struct {
+	client *serviceClient
+}
+
+// NewListsClient creates a ListsClient using the API endpoint and access token
+// of the request Context.
+func NewListsClient(c *Context) *ListsClient {
+	return NewServiceClientFactory(c).ListsClient()
+}
+
+// Lists returns the metadata of the user's lists.
+func (l *ListsClient) Lists(ctx context.Context) ([]ListMetadata, error) {
+	var resp struct {
+		Lists []ListMetadata `json:"lists"`
+	}
+	err := l.client.do(ctx, http.MethodGet, householdListsPath, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Lists, nil
+}
+
+// List returns the list with listID and its items with status.
+func (l *ListsClient) List(ctx context.Context, listID, status string) (*List, error) {
+	list := &List{}
+	err := l.client.do(ctx, http.MethodGet, listPath(listID)+"/"+url.PathEscape(status), nil, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateList creates an active list named name.
+func (l *ListsClient) CreateList(ctx context.Context, name string) (*ListMetadata, error) {
+	req := struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}{name, ListStateActive}
+	list := &ListMetadata{}
+	err := l.client.do(ctx, http.MethodPost, householdListsPath, req, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// UpdateList renames the list or changes its state. The version must match
+// the current version of the list.
+func (l *ListsClient) UpdateList(ctx context.Context, listID, name, state string, version int) (*ListMetadata, error) {
+	req := struct {
+		Name    string `json:"name"`
+		State   string `json:"state"`
+		Version int    `json:"version"`
+	}{name, state, version}
+	list := &ListMetadata{}
+	err := l.client.do(ctx, http.MethodPut, listPath(listID), req, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// DeleteList deletes the list with listID.
+func (l *ListsClient) DeleteList(ctx context.Context, listID string) error {
+	return l.client.do(ctx, http.MethodDelete, listPath(listID), nil, nil)
+}
+
+// Item returns the item with itemID.
+func (l *ListsClient) Item(ctx context.Context, listID, itemID string) (*ListItem, error) {
+	item := &ListItem{}
+	err := l.client.do(ctx, http.MethodGet, itemPath(listID, itemID), nil, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CreateItem adds an item with value and status to the list.
+func (l *ListsClient) CreateItem(ctx context.Context, listID, value, status string) (*ListItem, error) {
+	req := struct {
+		Value  string `json:"value"`
+		Status string `json:"status"`
+	}{value, status}
+	item := &ListItem{}
+	err := l.client.do(ctx, http.MethodPost, listPath(listID)+"/items", req, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem changes the value or status of an item, for example to mark it
+// ListItemCompleted. The version must match the current version of the item.
+func (l *ListsClient) UpdateItem(ctx context.Context, listID, itemID, value, status string, version int) (*ListItem, error) {
+	req := struct {
+		Value   string `json:"value"`
+		Status  string `json:"status"`
+		Version int    `json:"version"`
+	}{value, status, version}
+	item := &ListItem{}
+	err := l.client.do(ctx, http.MethodPut, itemPath(listID, itemID), req, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem deletes the item with itemID.
+func (l *ListsClient) DeleteItem(ctx context.Context, listID, itemID string) error {
+	return l.client.do(ctx, http.MethodDelete, itemPath(listID, itemID), nil, nil)
+}
+
+func listPath(listID string) string {
+	return householdListsPath + url.PathEscape(listID)
+}
+
+func itemPath(listID, itemID string) string {
+	return listPath(listID) + "/items/" + url.PathEscape(itemID)
+}
+
+// HouseholdListEventRequest contains the payload of AlexaHouseholdListEvent.*
+// requests, sent when a list is changed outside of the skill.
+type HouseholdListEventRequest struct {
+	Type                string `json:"type"`
+	EventCreationTime   string `json:"eventCreationTime"`
+	EventPublishingTime string `json:"eventPublishingTime"`
+	Body                struct {
+		ListID      string   `json:"listId"`
+		ListItemIDs []string `json:"listItemIds"`
+	} `json:"body"`
+}
+
+// HouseholdListEvent decodes the payload of an AlexaHouseholdListEvent.* request.
+func (r *Request) HouseholdListEvent() (*HouseholdListEventRequest, error) {
+	p := &HouseholdListEventRequest{}
+	err := r.decodeTypedPayload("AlexaHouseholdListEvent.", p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// HouseholdListEventHandler handles a decoded AlexaHouseholdListEvent.* request.
+type HouseholdListEventHandler func(context.Context, *HouseholdListEventRequest, *Context, *Response) error
+
+// HandleHouseholdListEvents registers handler for all AlexaHouseholdListEvent.*
+// request types. Handlers registered with HandleRequestType for an exact
+// event type take precedence.
+func (alexa *Alexa) HandleHouseholdListEvents(handler HouseholdListEventHandler) {
+	alexa.HandleRequestType("AlexaHouseholdListEvent.*", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		event, err := request.HouseholdListEvent()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, event, aContext, response)
+	})
+}
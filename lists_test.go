@@ -0,0 +1,165 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const listItemsCreatedString = `{
+	"version": "1.0",
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.ABC123"
+			},
+			"user": {
+				"userId": "amzn1.ask.account.[unique-value-here]"
+			},
+			"apiEndpoint": "https://api.amazonalexa.com"
+		}
+	},
+	"request": {
+		"type": "AlexaHouseholdListEvent.ItemsCreated",
+		"requestId": "amzn1.echo-api.request.list123",
+		"timestamp": "2019-10-04T17:36:04Z",
+		"eventCreationTime": "2019-10-04T17:36:03Z",
+		"eventPublishingTime": "2019-10-04T17:36:04Z",
+		"body": {
+			"listId": "list-1",
+			"listItemIds": ["item-1", "item-2"]
+		}
+	}
+}`
+
+func TestListsClient(t *testing.T) {
+	type call struct {
+		method, path string
+		body         map[string]interface{}
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		c := call{method: r.Method, path: r.URL.EscapedPath()}
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "GET /v2/householdlists/":
+			w.Write([]byte(`{"lists":[{"listId":"list-1","name":"Alexa shopping list","state":"active","version":1,"statusMap":[{"href":"/v2/householdlists/list-1/active","status":"active"}]}]}`))
+		case "GET /v2/householdlists/list-1/active":
+			w.Write([]byte(`{"listId":"list-1","name":"Alexa shopping list","state":"active","version":1,"items":[{"id":"item-1","version":1,"value":"milk","status":"active"}]}`))
+		case "POST /v2/householdlists/", "PUT /v2/householdlists/list-2":
+			w.Write([]byte(`{"listId":"list-2","name":"Camping","state":"active","version":1}`))
+		case "POST /v2/householdlists/list-1/items", "GET /v2/householdlists/list-1/items/item%2F2", "PUT /v2/householdlists/list-1/items/item%2F2":
+			w.Write([]byte(`{"id":"item/2","version":2,"value":"eggs","status":"completed"}`))
+		case "DELETE /v2/householdlists/list-2", "DELETE /v2/householdlists/list-1/items/item%2F2":
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewListsClient(newServiceContext(server.URL))
+
+	lists, err := client.Lists(ctx)
+	if err != nil {
+		t.Fatal("Error reading lists. " + err.Error())
+	}
+	if len(lists) != 1 || lists[0].ListID != "list-1" || lists[0].StatusMap[0].Status != ListItemActive {
+		t.Errorf("Unexpected lists %+v", lists)
+	}
+
+	list, err := client.List(ctx, "list-1", ListItemActive)
+	if err != nil {
+		t.Fatal("Error reading list. " + err.Error())
+	}
+	if len(list.Items) != 1 || list.Items[0].Value != "milk" {
+		t.Errorf("Unexpected list %+v", list)
+	}
+
+	created, err := client.CreateList(ctx, "Camping")
+	if err != nil {
+		t.Fatal("Error creating list. " + err.Error())
+	}
+	if created.ListID != "list-2" {
+		t.Errorf("Unexpected list %+v", created)
+	}
+	_, err = client.UpdateList(ctx, "list-2", "Camping", ListStateArchived, 1)
+	if err != nil {
+		t.Fatal("Error updating list. " + err.Error())
+	}
+	err = client.DeleteList(ctx, "list-2")
+	if err != nil {
+		t.Fatal("Error deleting list. " + err.Error())
+	}
+
+	_, err = client.CreateItem(ctx, "list-1", "eggs", ListItemActive)
+	if err != nil {
+		t.Fatal("Error creating item. " + err.Error())
+	}
+	item, err := client.Item(ctx, "list-1", "item/2")
+	if err != nil {
+		t.Fatal("Error reading item. " + err.Error())
+	}
+	if item.ID != "item/2" || item.Version != 2 {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	_, err = client.UpdateItem(ctx, "list-1", "item/2", "eggs", ListItemCompleted, 2)
+	if err != nil {
+		t.Fatal("Error updating item. " + err.Error())
+	}
+	err = client.DeleteItem(ctx, "list-1", "item/2")
+	if err != nil {
+		t.Fatal("Error deleting item. " + err.Error())
+	}
+
+	if len(calls) != 9 {
+		t.Fatalf("Expected 9 calls but was %d", len(calls))
+	}
+	if body := calls[2].body; body["name"] != "Camping" || body["state"] != ListStateActive {
+		t.Errorf("Unexpected create list body %v", body)
+	}
+	if body := calls[3].body; body["state"] != ListStateArchived || body["version"] != 1.0 {
+		t.Errorf("Unexpected update list body %v", body)
+	}
+	if body := calls[7].body; body["status"] != ListItemCompleted || body["version"] != 2.0 {
+		t.Errorf("Unexpected update item body %v", body)
+	}
+}
+
+func TestHandleHouseholdListEvents(t *testing.T) {
+	var request RequestEnvelope
+	err := json.Unmarshal([]byte(listItemsCreatedString), &request)
+	if err != nil {
+		t.Fatal("Error unmarshaling request.", err)
+	}
+	request.Request.Timestamp = time.Now().Format(time.RFC3339)
+
+	alexa := getAlexa()
+	var got *HouseholdListEventRequest
+	alexa.HandleHouseholdListEvents(func(ctx context.Context, event *HouseholdListEventRequest, aContext *Context, response *Response) error {
+		got = event
+		return nil
+	})
+	_, err = alexa.ProcessRequest(context.Background(), &request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if got == nil {
+		t.Fatal("Household list event handler was not called.")
+	}
+	if got.Type != HouseholdListEventItemsCreated || got.Body.ListID != "list-1" || len(got.Body.ListItemIDs) != 2 {
+		t.Errorf("Unexpected event %+v", got)
+	}
+	if got.EventCreationTime != "2019-10-04T17:36:03Z" {
+		t.Errorf("Expected eventCreationTime 2019-10-04T17:36:03Z but was %s", got.EventCreationTime)
+	}
+}
@@ -0,0 +1,180 @@
+package alexa
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// redactedValue replaces the value of tokens in log output.
+const redactedValue = "[REDACTED]"
+
+// redactedKeys are the lower case attribute keys whose values are redacted.
+var redactedKeys = map[string]bool{
+	"accesstoken":    true,
+	"apiaccesstoken": true,
+	"consenttoken":   true,
+}
+
+// LoggerFromContext returns the request scoped logger passed to handlers by
+// ProcessRequest, or the default logger if ctx does not contain one. The
+// logger includes the requestId, requestType, locale, sessionId and intent of
+// the request being processed.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+func withLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// logger returns the configured Logger wrapped to redact tokens.
+func (alexa *Alexa) logger() *slog.Logger {
+	l := alexa.Logger
+	if l == nil {
+		l = slog.Default()
+	}
+	return slog.New(NewRedactingHandler(l.Handler()))
+}
+
+// requestLogger returns a logger with the fields identifying requestEnv.
+func (alexa *Alexa) requestLogger(requestEnv *RequestEnvelope) *slog.Logger {
+	request := requestEnv.Request
+	attrs := []any{
+		slog.String("requestId", request.RequestID),
+		slog.String("requestType", request.Type),
+		slog.String("locale", request.Locale),
+	}
+	if requestEnv.Session != nil {
+		attrs = append(attrs, slog.String("sessionId", requestEnv.Session.SessionID))
+	}
+	if request.Type == intentRequestName {
+		attrs = append(attrs, slog.String("intent", request.Intent.Name))
+	}
+	return alexa.logger().With(attrs...)
+}
+
+// NewRedactingHandler wraps h so that the values of attributes named
+// accessToken, apiAccessToken or consentToken, in any group, are replaced
+// before they are logged.
+func NewRedactingHandler(h slog.Handler) slog.Handler {
+	if _, ok := h.(*redactingHandler); ok {
+		return h
+	}
+	return &redactingHandler{handler: h}
+}
+
+type redactingHandler struct {
+	handler slog.Handler
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &redactingHandler{handler: h.handler.WithAttrs(redacted)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{handler: h.handler.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = redactAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	}
+	if redactedKeys[strings.ToLower(a.Key)] {
+		a.Value = slog.StringValue(redactToken(a.Value.String()))
+	}
+	return a
+}
+
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// LogValue implements slog.LogValuer, logging the identifying fields of the
+// request with tokens redacted.
+func (requestEnv *RequestEnvelope) LogValue() slog.Value {
+	if requestEnv == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := []slog.Attr{slog.String("version", requestEnv.Version)}
+	if requestEnv.Request != nil {
+		attrs = append(attrs, slog.Group("request",
+			slog.String("type", requestEnv.Request.Type),
+			slog.String("requestId", requestEnv.Request.RequestID),
+			slog.String("timestamp", requestEnv.Request.Timestamp),
+			slog.String("locale", requestEnv.Request.Locale),
+			slog.String("intent", requestEnv.Request.Intent.Name),
+			slog.String("dialogState", requestEnv.Request.DialogState),
+		))
+	}
+	if requestEnv.Session != nil {
+		attrs = append(attrs, slog.Any("session", requestEnv.Session))
+	}
+	if requestEnv.Context != nil {
+		attrs = append(attrs, slog.Any("context", requestEnv.Context))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, logging the session with the access
+// token redacted. Attribute values are not logged.
+func (s *Session) LogValue() slog.Value {
+	if s == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.GroupValue(
+		slog.Bool("new", s.New),
+		slog.String("sessionId", s.SessionID),
+		slog.String("applicationId", s.Application.ApplicationID),
+		slog.String("userId", s.User.UserID),
+		slog.String("accessToken", redactToken(s.User.AccessToken)),
+		slog.Int("attributes", len(s.Attributes.String)),
+	)
+}
+
+// LogValue implements slog.LogValuer, logging the context with the access,
+// API access and consent tokens redacted.
+func (c *Context) LogValue() slog.Value {
+	if c == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.GroupValue(
+		slog.String("applicationId", c.System.Application.ApplicationID),
+		slog.String("deviceId", c.System.Device.DeviceID),
+		slog.String("userId", c.System.User.UserID),
+		slog.String("accessToken", redactToken(c.System.User.AccessToken)),
+		slog.String("consentToken", redactToken(c.System.User.Permissions.ConsentToken)),
+		slog.String("personId", c.System.Person.PersonID),
+		slog.String("apiEndpoint", c.System.APIEndpoint),
+		slog.String("apiAccessToken", redactToken(c.System.APIAccessToken)),
+	)
+}
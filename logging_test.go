@@ -0,0 +1,70 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestAlexaLoggerRedactsTokens(t *testing.T) {
+	request := createRecipeRequest()
+	request.Session.User.AccessToken = "secret-session-token"
+	request.Context.System.User.AccessToken = "secret-user-token"
+	request.Context.System.User.Permissions.ConsentToken = "secret-consent-token"
+	request.Context.System.APIAccessToken = "secret-api-token"
+
+	var buf bytes.Buffer
+	router := &IntentRouter{Fallback: func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		SetAttr(session, "pin", "8675309")
+		LoggerFromContext(ctx).Info("Handling intent.", "apiAccessToken", aContext.System.APIAccessToken, "context", aContext)
+		return nil
+	}}
+	alexa := getAlexaWithHandler(router)
+	alexa.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"secret-session-token", "secret-user-token", "secret-consent-token", "secret-api-token", "8675309"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Log output should not contain %s but was %s", secret, out)
+		}
+	}
+	for _, field := range []string{`"requestId":"amzn1.echo-api.request.xyz789"`, `"intent":"RecipeIntent"`, `"locale":"en-US"`, `"sessionId":"amzn1.echo-api.session.[unique-value-here]"`, redactedValue} {
+		if !strings.Contains(out, field) {
+			t.Errorf("Log output should contain %s but was %s", field, out)
+		}
+	}
+}
+
+func TestAlexaLoggerLevels(t *testing.T) {
+	request := createRecipeRequest()
+
+	var buf bytes.Buffer
+	alexa := getAlexa()
+	alexa.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log output at level WARN but was %s", buf.String())
+	}
+
+	alexa.RequestHandler = &emptyRequestHandler{OnIntentThrowsErr: true}
+	alexa.ProcessRequest(context.Background(), request)
+	if !strings.Contains(buf.String(), "Error handling OnIntent.") {
+		t.Errorf("Expected the OnIntent error to be logged but was %s", buf.String())
+	}
+}
+
+func TestLoggerFromContextDefault(t *testing.T) {
+	if LoggerFromContext(context.Background()) != slog.Default() {
+		t.Error("Expected LoggerFromContext to return the default logger for a context without a logger.")
+	}
+}
@@ -0,0 +1,137 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Login with Amazon scopes for the Alexa APIs called outside of a request.
+const (
+	ScopeProactiveEvents = "alexa::proactive_events"
+	ScopeSkillMessaging  = "alexa:skill_messaging"
+)
+
+const lwaDefaultTokenURL = "https://api.amazon.com/auth/o2/token"
+
+// lwaExpiryMargin is how long before expiry a cached token is refreshed. It
+// is reduced to a tenth of the token lifetime for short lived tokens.
+const lwaExpiryMargin = time.Minute
+
+// AccessTokenProvider provides access tokens for the Alexa APIs.
+type AccessTokenProvider interface {
+	AccessToken(ctx context.Context) (string, error)
+}
+
+// LWATokenProvider is an AccessTokenProvider that obtains tokens from Login
+// with Amazon using the skill's client credentials. Tokens are cached until
+// shortly before they expire.
+type LWATokenProvider struct {
+	// ClientID and ClientSecret are shown on the Permissions page of the skill
+	// in the developer console.
+	ClientID     string
+	ClientSecret string
+	// Scope is the scope requested, such as ScopeProactiveEvents.
+	Scope string
+	// TokenURL overrides the Login with Amazon token endpoint.
+	TokenURL   string
+	HTTPClient *http.Client
+	// Clock is used to check token expiry. Defaults to the system clock.
+	Clock Clock
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewLWATokenProvider creates an LWATokenProvider for scope.
+func NewLWATokenProvider(clientID, clientSecret, scope string) *LWATokenProvider {
+	return &LWATokenProvider{ClientID: clientID, ClientSecret: clientSecret, Scope: scope}
+}
+
+// AccessToken returns the cached token, requesting a new one if it has expired.
+func (p *LWATokenProvider) AccessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && p.now().Before(p.expiry) {
+		return p.token, nil
+	}
+	token, expiresIn, err := p.requestToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.token = token
+	margin := lwaExpiryMargin
+	if margin > expiresIn/10 {
+		margin = expiresIn / 10
+	}
+	p.expiry = p.now().Add(expiresIn - margin)
+	return token, nil
+}
+
+func (p *LWATokenProvider) requestToken(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+	form.Set("scope", p.Scope)
+
+	tokenURL := p.TokenURL
+	if tokenURL == "" {
+		tokenURL = lwaDefaultTokenURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = json.Unmarshal(b, &token)
+	if resp.StatusCode != http.StatusOK {
+		if token.Error != "" {
+			return "", 0, errors.New("login with amazon returned " + resp.Status + ": " + token.Error + " " + token.ErrorDescription)
+		}
+		return "", 0, errors.New("login with amazon returned " + resp.Status)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if token.AccessToken == "" {
+		return "", 0, errors.New("login with amazon response did not contain an access token")
+	}
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
+
+func (p *LWATokenProvider) now() time.Time {
+	if p.Clock != nil {
+		return p.Clock.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,118 @@
+package alexa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newLWAServer(t *testing.T, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		err := r.ParseForm()
+		if err != nil {
+			t.Error("Error parsing form. " + err.Error())
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "client" ||
+			r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != ScopeProactiveEvents {
+			t.Errorf("Unexpected token request %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if *calls == 1 {
+			w.Write([]byte(`{"access_token":"token-1","expires_in":3600,"scope":"alexa::proactive_events","token_type":"bearer"}`))
+		} else {
+			w.Write([]byte(`{"access_token":"token-2","expires_in":3600,"scope":"alexa::proactive_events","token_type":"bearer"}`))
+		}
+	}))
+}
+
+func TestLWATokenProviderCaches(t *testing.T) {
+	var calls int
+	server := newLWAServer(t, &calls)
+	defer server.Close()
+
+	clock := &mutableClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	provider := NewLWATokenProvider("client", "secret", ScopeProactiveEvents)
+	provider.TokenURL = server.URL
+	provider.Clock = clock
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		token, err := provider.AccessToken(ctx)
+		if err != nil {
+			t.Fatal("Error getting token. " + err.Error())
+		}
+		if token != "token-1" {
+			t.Errorf("Expected token-1 but was %s", token)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the token to be cached, calls %d", calls)
+	}
+
+	clock.now = clock.now.Add(59*time.Minute + time.Second)
+	token, err := provider.AccessToken(ctx)
+	if err != nil {
+		t.Fatal("Error getting token. " + err.Error())
+	}
+	if token != "token-2" || calls != 2 {
+		t.Errorf("Expected a new token before expiry but was %s, calls %d", token, calls)
+	}
+}
+
+func TestLWATokenProviderShortLivedToken(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","expires_in":30,"token_type":"bearer"}`))
+	}))
+	defer server.Close()
+
+	clock := &mutableClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	provider := &LWATokenProvider{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL, Clock: clock}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := provider.AccessToken(ctx)
+		if err != nil {
+			t.Fatal("Error getting token. " + err.Error())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected a 30 second token to be cached, calls %d", calls)
+	}
+
+	clock.now = clock.now.Add(27 * time.Second)
+	_, err := provider.AccessToken(ctx)
+	if err != nil {
+		t.Fatal("Error getting token. " + err.Error())
+	}
+	if calls != 2 {
+		t.Errorf("Expected a new token within a tenth of the lifetime of expiry, calls %d", calls)
+	}
+}
+
+func TestLWATokenProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_client","error_description":"Client authentication failed"}`))
+	}))
+	defer server.Close()
+
+	provider := &LWATokenProvider{ClientID: "client", ClientSecret: "wrong", Scope: ScopeProactiveEvents, TokenURL: server.URL}
+	_, err := provider.AccessToken(context.Background())
+	if err == nil || err.Error() != "login with amazon returned 401 Unauthorized: invalid_client Client authentication failed" {
+		t.Errorf("Expected invalid_client error but was %v", err)
+	}
+}
+
+type mutableClock struct {
+	now time.Time
+}
+
+func (c *mutableClock) Now() time.Time {
+	return c.now
+}
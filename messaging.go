@@ -0,0 +1,78 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Limits on the time a skill message is kept while the skill cannot be reached.
+const (
+	MinSkillMessageTTL     = time.Minute
+	MaxSkillMessageTTL     = 7 * 24 * time.Hour
+	DefaultSkillMessageTTL = time.Hour
+)
+
+// SkillMessagingClient sends messages to the skill on behalf of a user, for
+// example to update persistent attributes from a backend. Each message is
+// delivered to the skill as a Messaging.MessageReceived request. It is
+// authenticated with a token for the ScopeSkillMessaging scope.
+type SkillMessagingClient struct {
+	TokenProvider AccessTokenProvider
+	// APIEndpoint is the Alexa API endpoint for the region of the users.
+	// Defaults to DefaultAPIEndpoint.
+	APIEndpoint string
+	HTTPClient  *http.Client
+}
+
+// NewSkillMessagingClient creates a SkillMessagingClient with tokens from a
+// new LWATokenProvider for the skill's client credentials.
+func NewSkillMessagingClient(clientID, clientSecret string) *SkillMessagingClient {
+	return &SkillMessagingClient{TokenProvider: NewLWATokenProvider(clientID, clientSecret, ScopeSkillMessaging)}
+}
+
+// Send sends data to the skill for the user with userID. The message is
+// discarded if it cannot be delivered within ttl, which must be between
+// MinSkillMessageTTL and MaxSkillMessageTTL, or zero for DefaultSkillMessageTTL.
+func (c *SkillMessagingClient) Send(ctx context.Context, userID string, data map[string]interface{}, ttl time.Duration) error {
+	if userID == "" {
+		return errors.New("skill message requires a user ID")
+	}
+	if ttl == 0 {
+		ttl = DefaultSkillMessageTTL
+	}
+	if ttl < MinSkillMessageTTL || ttl > MaxSkillMessageTTL {
+		return errors.New("skill message TTL " + ttl.String() + " must be between " + MinSkillMessageTTL.String() + " and " + MaxSkillMessageTTL.String())
+	}
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	client, err := newTokenServiceClient(ctx, c.TokenProvider, c.APIEndpoint, c.HTTPClient)
+	if err != nil {
+		return err
+	}
+	message := struct {
+		Data                map[string]interface{} `json:"data"`
+		ExpiresAfterSeconds int                    `json:"expiresAfterSeconds"`
+	}{data, int(ttl / time.Second)}
+	return client.do(ctx, http.MethodPost, "/v1/skillmessages/users/"+url.PathEscape(userID), message, nil)
+}
+
+// MessageReceivedHandler handles a decoded Messaging.MessageReceived request.
+// Persistent attributes are available from PersistentAttributes(ctx), keyed by
+// the user the message was sent for.
+type MessageReceivedHandler func(context.Context, *MessageReceivedRequest, *Context, *Response) error
+
+// HandleMessageReceived registers handler for Messaging.MessageReceived requests.
+func (alexa *Alexa) HandleMessageReceived(handler MessageReceivedHandler) {
+	alexa.HandleRequestType(MessagingMessageReceived, func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		message, err := request.MessageReceived()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, message, aContext, response)
+	})
+}
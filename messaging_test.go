@@ -0,0 +1,118 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const messageReceivedString = `{
+	"version": "1.0",
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.ABC123"
+			},
+			"user": {
+				"userId": "amzn1.ask.account.user1"
+			},
+			"apiEndpoint": "https://api.amazonalexa.com"
+		}
+	},
+	"request": {
+		"type": "Messaging.MessageReceived",
+		"requestId": "amzn1.echo-api.request.msg123",
+		"timestamp": "2019-10-04T17:36:04Z",
+		"message": {
+			"orderStatus": "shipped"
+		}
+	}
+}`
+
+func TestSkillMessagingClientSend(t *testing.T) {
+	var path string
+	var body struct {
+		Data                map[string]interface{} `json:"data"`
+		ExpiresAfterSeconds int                    `json:"expiresAfterSeconds"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer lwa-token" {
+			t.Errorf("Expected LWA bearer token but was %s", auth)
+		}
+		path = r.URL.EscapedPath()
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := &SkillMessagingClient{TokenProvider: staticTokenProvider("lwa-token"), APIEndpoint: server.URL}
+	err := client.Send(context.Background(), "amzn1.ask.account.user1", map[string]interface{}{"orderStatus": "shipped"}, 2*time.Hour)
+	if err != nil {
+		t.Fatal("Error sending message. " + err.Error())
+	}
+	if path != "/v1/skillmessages/users/amzn1.ask.account.user1" {
+		t.Errorf("Unexpected path %s", path)
+	}
+	if body.Data["orderStatus"] != "shipped" || body.ExpiresAfterSeconds != 7200 {
+		t.Errorf("Unexpected message %+v", body)
+	}
+
+	err = client.Send(context.Background(), "amzn1.ask.account.user1", nil, 0)
+	if err != nil {
+		t.Fatal("Error sending message. " + err.Error())
+	}
+	if body.ExpiresAfterSeconds != 3600 {
+		t.Errorf("Expected the default TTL of 3600 seconds but was %d", body.ExpiresAfterSeconds)
+	}
+}
+
+func TestSkillMessagingClientValidation(t *testing.T) {
+	client := &SkillMessagingClient{TokenProvider: staticTokenProvider("lwa-token"), APIEndpoint: "http://127.0.0.1:0"}
+	for _, ttl := range []time.Duration{time.Second, 8 * 24 * time.Hour} {
+		err := client.Send(context.Background(), "user", nil, ttl)
+		if err == nil {
+			t.Errorf("Expected an error for TTL %s", ttl)
+		}
+	}
+	err := client.Send(context.Background(), "", nil, 0)
+	if err == nil {
+		t.Error("Expected an error for an empty user ID.")
+	}
+}
+
+func TestHandleMessageReceived(t *testing.T) {
+	var request RequestEnvelope
+	err := json.Unmarshal([]byte(messageReceivedString), &request)
+	if err != nil {
+		t.Fatal("Error unmarshaling request.", err)
+	}
+	request.Request.Timestamp = time.Now().Format(time.RFC3339)
+
+	adapter := NewMemoryPersistenceAdapter()
+	alexa := getAlexa()
+	alexa.PersistenceAdapter = adapter
+	alexa.HandleMessageReceived(func(ctx context.Context, message *MessageReceivedRequest, aContext *Context, response *Response) error {
+		PersistentAttributes(ctx).Set(map[string]interface{}{"orderStatus": message.Message["orderStatus"]})
+		return nil
+	})
+
+	responseEnv, err := alexa.ProcessRequest(context.Background(), &request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	b, _ := json.Marshal(responseEnv)
+	if string(b) != `{"version":"1.0","response":{}}` {
+		t.Errorf("Expected an empty response but was %s", b)
+	}
+
+	attributes, err := adapter.GetAttributes(context.Background(), "amzn1.ask.account.user1")
+	if err != nil {
+		t.Fatal("Error reading attributes. " + err.Error())
+	}
+	if attributes["orderStatus"] != "shipped" {
+		t.Errorf("Expected the message to update persistent attributes but was %v", attributes)
+	}
+}
@@ -0,0 +1,261 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// In-skill product types, entitlement and purchasable states.
+const (
+	ProductTypeSubscription = "SUBSCRIPTION"
+	ProductTypeEntitlement  = "ENTITLEMENT"
+	ProductTypeConsumable   = "CONSUMABLE"
+	Entitled                = "ENTITLED"
+	NotEntitled             = "NOT_ENTITLED"
+	Purchasable             = "PURCHASABLE"
+	NotPurchasable          = "NOT_PURCHASABLE"
+)
+
+// Names of the Connections.SendRequest purchase requests.
+const (
+	PurchaseBuy    = "Buy"
+	PurchaseUpsell = "Upsell"
+	PurchaseCancel = "Cancel"
+)
+
+// Purchase results reported in the Connections.Response to a purchase request.
+const (
+	PurchaseResultAccepted         = "ACCEPTED"
+	PurchaseResultDeclined         = "DECLINED"
+	PurchaseResultAlreadyPurchased = "ALREADY_PURCHASED"
+	PurchaseResultPendingPurchase  = "PENDING_PURCHASE"
+	PurchaseResultError            = "ERROR"
+)
+
+const inSkillProductsPath = "/v1/users/~current/skills/~current/inSkillProducts"
+
+// InSkillProduct describes an in-skill product and whether the user owns it.
+type InSkillProduct struct {
+	ProductID              string `json:"productId"`
+	ReferenceName          string `json:"referenceName"`
+	Type                   string `json:"type"`
+	Name                   string `json:"name"`
+	Summary                string `json:"summary"`
+	Entitled               string `json:"entitled"`
+	EntitlementReason      string `json:"entitlementReason"`
+	Purchasable            string `json:"purchasable"`
+	ActiveEntitlementCount int    `json:"activeEntitlementCount"`
+	PurchaseMode           string `json:"purchaseMode"`
+}
+
+// IsEntitled reports whether the user owns the product.
+func (p *InSkillProduct) IsEntitled() bool {
+	return p.Entitled == Entitled
+}
+
+// IsPurchasable reports whether the user can buy the product.
+func (p *InSkillProduct) IsPurchasable() bool {
+	return p.Purchasable == Purchasable
+}
+
+// InSkillProductsFilter restricts the products returned by Products. Empty
+// fields are not filtered on.
+type InSkillProductsFilter struct {
+	ProductType string
+	Purchasable string
+	Entitled    string
+	NextToken   string
+	MaxResults  int
+}
+
+// InSkillProducts is a page of in-skill products.
+type InSkillProducts struct {
+	InSkillProducts []InSkillProduct `json:"inSkillProducts"`
+	IsTruncated     bool             `json:"isTruncated"`
+	NextToken       string           `json:"nextToken"`
+}
+
+// MonetizationClient lists the in-skill products of the skill with the
+// Monetization Service. Product names and summaries are returned in the
+// locale the client was created with.
+type MonetizationClient struct {
+	client *serviceClient
+}
+
+// NewMonetizationClient creates a MonetizationClient using the API endpoint
+// and access token of the request Context. The locale is usually the Locale
+// of the Request.
+func NewMonetizationClient(c *Context, locale string) *MonetizationClient {
+	return NewServiceClientFactory(c).MonetizationClient(locale)
+}
+
+// Products returns the in-skill products matching filter, which may be nil.
+func (m *MonetizationClient) Products(ctx context.Context, filter *InSkillProductsFilter) (*InSkillProducts, error) {
+	query := url.Values{}
+	if filter != nil {
+		setQuery(query, "productType", filter.ProductType)
+		setQuery(query, "purchasable", filter.Purchasable)
+		setQuery(query, "entitled", filter.Entitled)
+		setQuery(query, "nextToken", filter.NextToken)
+		if filter.MaxResults > 0 {
+			query.Set("maxResults", strconv.Itoa(filter.MaxResults))
+		}
+	}
+	path := inSkillProductsPath
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	products := &InSkillProducts{}
+	err := m.client.do(ctx, http.MethodGet, path, nil, products)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// Product returns the in-skill product with productID.
+func (m *MonetizationClient) Product(ctx context.Context, productID string) (*InSkillProduct, error) {
+	product := &InSkillProduct{}
+	err := m.client.do(ctx, http.MethodGet, inSkillProductsPath+"/"+url.PathEscape(productID), nil, product)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// ErrInvalidPurchaseDirective reports that a purchase directive could not be
+// added because a required field is empty.
+var ErrInvalidPurchaseDirective = errors.New("invalid purchase directive")
+
+// ConnectionsSendRequestDirective starts a purchase flow handled by Alexa.
+// The result is sent to the skill as a Connections.Response request.
+type ConnectionsSendRequestDirective struct {
+	Type    string      `json:"type"`
+	Name    string      `json:"name"`
+	Payload interface{} `json:"payload"`
+	Token   string      `json:"token"`
+}
+
+type purchasePayload struct {
+	InSkillProduct struct {
+		ProductID string `json:"productId"`
+	} `json:"InSkillProduct"`
+	UpsellMessage string `json:"upsellMessage,omitempty"`
+}
+
+// AddBuyDirective asks Alexa to offer productID to the user. The token is
+// returned in the PurchaseResult.
+func (r *Response) AddBuyDirective(productID, token string) {
+	r.addPurchaseDirective(PurchaseBuy, productID, "", token)
+}
+
+// AddUpsellDirective asks Alexa to offer productID after upsellMessage is
+// spoken. The upsellMessage is required, and no directive is added if it is
+// empty.
+func (r *Response) AddUpsellDirective(productID, upsellMessage, token string) error {
+	if upsellMessage == "" {
+		return fmt.Errorf("%w: upsell message is empty", ErrInvalidPurchaseDirective)
+	}
+	r.addPurchaseDirective(PurchaseUpsell, productID, upsellMessage, token)
+	return nil
+}
+
+// AddCancelDirective asks Alexa to cancel or refund productID.
+func (r *Response) AddCancelDirective(productID, token string) {
+	r.addPurchaseDirective(PurchaseCancel, productID, "", token)
+}
+
+func (r *Response) addPurchaseDirective(name, productID, upsellMessage, token string) {
+	payload := purchasePayload{UpsellMessage: upsellMessage}
+	payload.InSkillProduct.ProductID = productID
+	r.Directives = append(r.Directives, ConnectionsSendRequestDirective{
+		Type:    "Connections.SendRequest",
+		Name:    name,
+		Payload: payload,
+		Token:   token,
+	})
+}
+
+// PurchaseResult is the result of a Buy, Upsell or Cancel request.
+type PurchaseResult struct {
+	// Name is PurchaseBuy, PurchaseUpsell or PurchaseCancel.
+	Name string
+	// Token is the token passed with the directive.
+	Token string
+	// Result is one of the PurchaseResult constants. It is
+	// PurchaseResultError if Alexa reported an error status.
+	Result    string
+	ProductID string
+	// StatusCode and Message are the status reported by Alexa.
+	StatusCode string
+	Message    string
+}
+
+// PurchaseResultHandler handles the result of a Buy, Upsell or Cancel request.
+type PurchaseResultHandler func(context.Context, *PurchaseResult, *Session, *Context, *Response) error
+
+// PurchaseResult decodes the payload of a Connections.Response to a purchase request.
+func (r *Request) PurchaseResult() (*PurchaseResult, error) {
+	resp, err := r.ConnectionsResponse()
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		PurchaseResult string `json:"purchaseResult"`
+		ProductID      string `json:"productId"`
+		Message        string `json:"message"`
+	}
+	if len(resp.Payload) > 0 {
+		err = json.Unmarshal(resp.Payload, &payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &PurchaseResult{
+		Name:       resp.Name,
+		Token:      resp.Token,
+		Result:     payload.PurchaseResult,
+		ProductID:  payload.ProductID,
+		StatusCode: resp.Status.Code,
+		Message:    payload.Message,
+	}
+	if resp.Status.Code != "200" || result.Result == "" {
+		result.Result = PurchaseResultError
+		if resp.Status.Message != "" {
+			result.Message = resp.Status.Message
+		}
+	}
+	return result, nil
+}
+
+// HandlePurchaseResults registers handler for Connections.Response requests
+// answering a Buy, Upsell or Cancel request. Other Connections.Response
+// requests are passed to OnUnhandled if the RequestHandler implements
+// UnhandledRequestHandler.
+func (alexa *Alexa) HandlePurchaseResults(handler PurchaseResultHandler) {
+	alexa.HandleRequestType(ConnectionsResponse, func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		switch request.Name {
+		case PurchaseBuy, PurchaseUpsell, PurchaseCancel:
+		default:
+			return alexa.dispatchUnhandled(ctx, request, session, aContext, response)
+		}
+		result, err := request.PurchaseResult()
+		if err != nil {
+			return err
+		}
+		return handler(ctx, result, session, aContext, response)
+	})
+}
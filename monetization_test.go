@@ -0,0 +1,192 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const buyResponseString = `{
+	"version": "1.0",
+	"session": {
+		"new": true,
+		"sessionId": "amzn1.echo-api.session.[unique-value-here]",
+		"application": {
+			"applicationId": "amzn1.ask.skill.ABC123"
+		},
+		"user": {
+			"userId": "amzn1.ask.account.[unique-value-here]"
+		}
+	},
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.ABC123"
+			},
+			"user": {
+				"userId": "amzn1.ask.account.[unique-value-here]"
+			}
+		}
+	},
+	"request": {
+		"type": "Connections.Response",
+		"requestId": "amzn1.echo-api.request.buy123",
+		"timestamp": "2019-10-04T17:36:04Z",
+		"locale": "en-US",
+		"name": "Buy",
+		"status": {
+			"code": "200",
+			"message": "OK"
+		},
+		"payload": {
+			"purchaseResult": "ACCEPTED",
+			"productId": "amzn1.adg.product.premium"
+		},
+		"token": "correlation-token"
+	}
+}`
+
+func TestMonetizationClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		if lang := r.Header.Get("Accept-Language"); lang != "de-DE" {
+			t.Errorf("Expected Accept-Language de-DE but was %s", lang)
+		}
+		switch r.URL.Path {
+		case "/v1/users/~current/skills/~current/inSkillProducts":
+			if q := r.URL.Query(); q.Get("entitled") != Entitled || q.Get("maxResults") != "10" || q.Has("productType") {
+				t.Errorf("Unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"inSkillProducts":[{"productId":"amzn1.adg.product.premium","referenceName":"premium","type":"ENTITLEMENT","name":"Premium","entitled":"ENTITLED","purchasable":"NOT_PURCHASABLE","activeEntitlementCount":1}],"isTruncated":true,"nextToken":"next"}`))
+		case "/v1/users/~current/skills/~current/inSkillProducts/amzn1.adg.product.premium":
+			w.Write([]byte(`{"productId":"amzn1.adg.product.premium","type":"ENTITLEMENT","entitled":"NOT_ENTITLED","purchasable":"PURCHASABLE"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewMonetizationClient(newServiceContext(server.URL), "de-DE")
+
+	products, err := client.Products(ctx, &InSkillProductsFilter{Entitled: Entitled, MaxResults: 10})
+	if err != nil {
+		t.Fatal("Error listing products. " + err.Error())
+	}
+	if len(products.InSkillProducts) != 1 || !products.IsTruncated || products.NextToken != "next" {
+		t.Fatalf("Unexpected products %+v", products)
+	}
+	if p := products.InSkillProducts[0]; !p.IsEntitled() || p.IsPurchasable() || p.ActiveEntitlementCount != 1 {
+		t.Errorf("Unexpected product %+v", p)
+	}
+
+	product, err := client.Product(ctx, "amzn1.adg.product.premium")
+	if err != nil {
+		t.Fatal("Error getting product. " + err.Error())
+	}
+	if product.IsEntitled() || !product.IsPurchasable() {
+		t.Errorf("Unexpected product %+v", product)
+	}
+}
+
+func TestPurchaseDirectives(t *testing.T) {
+	response := &Response{}
+	response.AddBuyDirective("product-1", "buy-token")
+	err := response.AddUpsellDirective("product-1", "Would you like to hear about premium?", "upsell-token")
+	if err != nil {
+		t.Fatal("Error adding upsell directive. " + err.Error())
+	}
+	response.AddCancelDirective("product-1", "cancel-token")
+
+	b, err := json.Marshal(response.Directives)
+	if err != nil {
+		t.Fatal("Error marshalling directives. " + err.Error())
+	}
+	expected := `[{"type":"Connections.SendRequest","name":"Buy","payload":{"InSkillProduct":{"productId":"product-1"}},"token":"buy-token"},` +
+		`{"type":"Connections.SendRequest","name":"Upsell","payload":{"InSkillProduct":{"productId":"product-1"},"upsellMessage":"Would you like to hear about premium?"},"token":"upsell-token"},` +
+		`{"type":"Connections.SendRequest","name":"Cancel","payload":{"InSkillProduct":{"productId":"product-1"}},"token":"cancel-token"}]`
+	if string(b) != expected {
+		t.Errorf("Expected %s but was %s", expected, b)
+	}
+}
+
+func TestUpsellDirectiveRequiresMessage(t *testing.T) {
+	response := &Response{}
+	err := response.AddUpsellDirective("product-1", "", "upsell-token")
+	if !errors.Is(err, ErrInvalidPurchaseDirective) {
+		t.Errorf("Expected ErrInvalidPurchaseDirective but was %v", err)
+	}
+	if len(response.Directives) != 0 {
+		t.Errorf("Expected no directives but was %d", len(response.Directives))
+	}
+}
+
+func createBuyResponseRequest(t *testing.T) *RequestEnvelope {
+	var request RequestEnvelope
+	err := json.Unmarshal([]byte(buyResponseString), &request)
+	if err != nil {
+		t.Fatal("Error unmarshaling request.", err)
+	}
+	request.Request.Timestamp = time.Now().Format(time.RFC3339)
+	return &request
+}
+
+func TestHandlePurchaseResults(t *testing.T) {
+	alexa := getAlexa()
+	var got *PurchaseResult
+	alexa.HandlePurchaseResults(func(ctx context.Context, result *PurchaseResult, session *Session, aContext *Context, response *Response) error {
+		got = result
+		return nil
+	})
+
+	_, err := alexa.ProcessRequest(context.Background(), createBuyResponseRequest(t))
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	expected := PurchaseResult{Name: PurchaseBuy, Token: "correlation-token", Result: PurchaseResultAccepted, ProductID: "amzn1.adg.product.premium", StatusCode: "200"}
+	if got == nil || *got != expected {
+		t.Errorf("Expected %+v but was %+v", expected, got)
+	}
+
+	request := createBuyResponseRequest(t)
+	request.Request.Name = PurchaseUpsell
+	request.Request.raw = []byte(`{"type":"Connections.Response","name":"Upsell","status":{"code":"500","message":"Internal error"},"token":"t"}`)
+	got = nil
+	_, err = alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if got == nil || got.Result != PurchaseResultError || got.Message != "Internal error" || got.StatusCode != "500" {
+		t.Errorf("Expected an ERROR result but was %+v", got)
+	}
+}
+
+func TestHandlePurchaseResultsOtherConnections(t *testing.T) {
+	handler := &unhandledRequestHandler{}
+	alexa := getAlexaWithHandler(handler)
+	called := false
+	alexa.HandlePurchaseResults(func(ctx context.Context, result *PurchaseResult, session *Session, aContext *Context, response *Response) error {
+		called = true
+		return nil
+	})
+
+	request := createBuyResponseRequest(t)
+	request.Request.Name = "AskFor"
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if called {
+		t.Error("Purchase handler should not be called for other Connections.Response requests.")
+	}
+	if handler.OnUnhandledType != ConnectionsResponse {
+		t.Errorf("Expected OnUnhandled to be called but was %s", handler.OnUnhandledType)
+	}
+}
@@ -0,0 +1,347 @@
+package alexa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PersistenceAdapter stores persistent attributes, which unlike session
+// attributes are kept across sessions. Implementations must be safe for
+// concurrent use.
+type PersistenceAdapter interface {
+	// GetAttributes returns the attributes stored under key, or nil if none are stored.
+	GetAttributes(ctx context.Context, key string) (map[string]interface{}, error)
+	// SaveAttributes replaces the attributes stored under key.
+	SaveAttributes(ctx context.Context, key string, attributes map[string]interface{}) error
+	// DeleteAttributes removes the attributes stored under key.
+	DeleteAttributes(ctx context.Context, key string) error
+}
+
+// PersistenceKeyFunc returns the key persistent attributes are stored under for a request.
+type PersistenceKeyFunc func(*RequestEnvelope) (string, error)
+
+// UserIDKey stores persistent attributes per Alexa user account.
+func UserIDKey(requestEnv *RequestEnvelope) (string, error) {
+	if requestEnv.Context != nil && requestEnv.Context.System.User.UserID != "" {
+		return requestEnv.Context.System.User.UserID, nil
+	}
+	if requestEnv.Session != nil && requestEnv.Session.User.UserID != "" {
+		return requestEnv.Session.User.UserID, nil
+	}
+	return "", errors.New("request does not contain a user ID")
+}
+
+// DeviceIDKey stores persistent attributes per device.
+func DeviceIDKey(requestEnv *RequestEnvelope) (string, error) {
+	if requestEnv.Context != nil && requestEnv.Context.System.Device.DeviceID != "" {
+		return requestEnv.Context.System.Device.DeviceID, nil
+	}
+	return "", errors.New("request does not contain a device ID")
+}
+
+// PersonIDKey stores persistent attributes per recognized speaker. Requests
+// from speakers that were not recognized return an error.
+func PersonIDKey(requestEnv *RequestEnvelope) (string, error) {
+	if requestEnv.Context != nil && requestEnv.Context.System.Person.PersonID != "" {
+		return requestEnv.Context.System.Person.PersonID, nil
+	}
+	return "", errors.New("request does not contain a person ID")
+}
+
+// AttributesManager provides access to the persistent attributes of the
+// current request. Attributes are loaded from the PersistenceAdapter on first
+// access and saved after the RequestHandler returns if they were changed
+// with Set or Delete.
+type AttributesManager struct {
+	mu         sync.Mutex
+	adapter    PersistenceAdapter
+	keyFunc    PersistenceKeyFunc
+	requestEnv *RequestEnvelope
+
+	key        string
+	attributes map[string]interface{}
+	loaded     bool
+	dirty      bool
+	deleted    bool
+}
+
+// PersistentAttributes returns the AttributesManager for the request being
+// processed, or nil if the Alexa has no PersistenceAdapter.
+func PersistentAttributes(ctx context.Context) *AttributesManager {
+	m, _ := ctx.Value(attributesManagerKey).(*AttributesManager)
+	return m
+}
+
+func withAttributesManager(ctx context.Context, m *AttributesManager) context.Context {
+	return context.WithValue(ctx, attributesManagerKey, m)
+}
+
+func (alexa *Alexa) newAttributesManager(requestEnv *RequestEnvelope) *AttributesManager {
+	keyFunc := alexa.PersistenceKey
+	if keyFunc == nil {
+		keyFunc = UserIDKey
+	}
+	return &AttributesManager{adapter: alexa.PersistenceAdapter, keyFunc: keyFunc, requestEnv: requestEnv}
+}
+
+// Get returns the persistent attributes, loading them if needed. The map
+// is never nil. Changes to the map are only saved if Set is called.
+func (m *AttributesManager) Get(ctx context.Context) (map[string]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.attributes, nil
+}
+
+// Set replaces the persistent attributes. They are saved after the
+// RequestHandler returns.
+func (m *AttributesManager) Set(attributes map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if attributes == nil {
+		attributes = make(map[string]interface{})
+	}
+	m.attributes = attributes
+	m.loaded = true
+	m.dirty = true
+	m.deleted = false
+}
+
+// Delete removes the persistent attributes. They are deleted from the
+// PersistenceAdapter after the RequestHandler returns.
+func (m *AttributesManager) Delete() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attributes = make(map[string]interface{})
+	m.loaded = true
+	m.dirty = false
+	m.deleted = true
+}
+
+// load fetches the attributes from the PersistenceAdapter if they have not been loaded.
+func (m *AttributesManager) load(ctx context.Context) error {
+	if m.loaded {
+		return nil
+	}
+	key, err := m.resolveKey()
+	if err != nil {
+		return err
+	}
+	attributes, err := m.adapter.GetAttributes(ctx, key)
+	if err != nil {
+		return err
+	}
+	if attributes == nil {
+		attributes = make(map[string]interface{})
+	}
+	m.attributes = attributes
+	m.loaded = true
+	return nil
+}
+
+func (m *AttributesManager) resolveKey() (string, error) {
+	if m.key != "" {
+		return m.key, nil
+	}
+	key, err := m.keyFunc(m.requestEnv)
+	if err != nil {
+		return "", err
+	}
+	m.key = key
+	return key, nil
+}
+
+// save writes changed attributes to the PersistenceAdapter.
+func (m *AttributesManager) save(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.dirty && !m.deleted {
+		return nil
+	}
+	key, err := m.resolveKey()
+	if err != nil {
+		return err
+	}
+	if m.deleted {
+		err = m.adapter.DeleteAttributes(ctx, key)
+	} else {
+		err = m.adapter.SaveAttributes(ctx, key, m.attributes)
+	}
+	if err != nil {
+		return err
+	}
+	m.dirty = false
+	m.deleted = false
+	return nil
+}
+
+// ErrNoPersistenceDir reports that a FilePersistenceAdapter has no directory.
+// Use NewFilePersistenceAdapter to create one.
+var ErrNoPersistenceDir = errors.New("file persistence adapter has no directory")
+
+// MemoryPersistenceAdapter is a PersistenceAdapter that keeps attributes in
+// memory. It is intended for tests and development. The zero value is ready
+// to use.
+type MemoryPersistenceAdapter struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+// NewMemoryPersistenceAdapter creates an empty MemoryPersistenceAdapter.
+func NewMemoryPersistenceAdapter() *MemoryPersistenceAdapter {
+	return &MemoryPersistenceAdapter{items: make(map[string][]byte)}
+}
+
+// GetAttributes returns a copy of the attributes stored under key.
+func (a *MemoryPersistenceAdapter) GetAttributes(ctx context.Context, key string) (map[string]interface{}, error) {
+	a.mu.Lock()
+	b, ok := a.items[key]
+	a.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	return decodeAttributes(b)
+}
+
+// SaveAttributes stores a copy of attributes under key.
+func (a *MemoryPersistenceAdapter) SaveAttributes(ctx context.Context, key string, attributes map[string]interface{}) error {
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.items == nil {
+		a.items = make(map[string][]byte)
+	}
+	a.items[key] = b
+	return nil
+}
+
+// DeleteAttributes removes the attributes stored under key.
+func (a *MemoryPersistenceAdapter) DeleteAttributes(ctx context.Context, key string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.items, key)
+	return nil
+}
+
+// FilePersistenceAdapter is a PersistenceAdapter that stores the attributes
+// for each key as a JSON file in a directory.
+type FilePersistenceAdapter struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFilePersistenceAdapter creates a FilePersistenceAdapter storing files in
+// dir, creating the directory if it does not exist.
+func NewFilePersistenceAdapter(dir string) (*FilePersistenceAdapter, error) {
+	if dir == "" {
+		return nil, ErrNoPersistenceDir
+	}
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePersistenceAdapter{dir: dir}, nil
+}
+
+// GetAttributes reads the attributes stored under key.
+func (a *FilePersistenceAdapter) GetAttributes(ctx context.Context, key string) (map[string]interface{}, error) {
+	path, err := a.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeAttributes(b)
+}
+
+// SaveAttributes writes attributes under key, replacing the file atomically.
+func (a *FilePersistenceAdapter) SaveAttributes(ctx context.Context, key string, attributes map[string]interface{}) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.CreateTemp(a.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// DeleteAttributes removes the file for key.
+func (a *FilePersistenceAdapter) DeleteAttributes(ctx context.Context, key string) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file for key. Keys are hashed as user IDs are too long
+// and contain characters that are not valid in file names.
+func (a *FilePersistenceAdapter) path(key string) (string, error) {
+	if a.dir == "" {
+		return "", ErrNoPersistenceDir
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(a.dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+func decodeAttributes(b []byte) (map[string]interface{}, error) {
+	var attributes map[string]interface{}
+	err := json.Unmarshal(b, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
@@ -0,0 +1,181 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPersistentAttributesLoadAndSave(t *testing.T) {
+	request := createRecipeRequest()
+	userID := request.Session.User.UserID
+	adapter := NewMemoryPersistenceAdapter()
+	ctx := context.Background()
+	adapter.SaveAttributes(ctx, userID, map[string]interface{}{"visits": 1})
+
+	router := &IntentRouter{Fallback: func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		m := PersistentAttributes(ctx)
+		attributes, err := m.Get(ctx)
+		if err != nil {
+			return err
+		}
+		attributes["visits"] = attributes["visits"].(float64) + 1
+		m.Set(attributes)
+		return nil
+	}}
+	alexa := getAlexaWithHandler(router)
+	alexa.PersistenceAdapter = adapter
+
+	_, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	attributes, err := adapter.GetAttributes(ctx, userID)
+	if err != nil {
+		t.Fatal("Error getting attributes.", err)
+	}
+	if attributes["visits"] != float64(2) {
+		t.Error("Expected visits to be saved as 2 but was", attributes["visits"])
+	}
+}
+
+func TestPersistentAttributesOnlySavedWhenChanged(t *testing.T) {
+	request := createRecipeRequest()
+	adapter := &countingPersistenceAdapter{PersistenceAdapter: NewMemoryPersistenceAdapter()}
+
+	alexa := getAlexa()
+	alexa.PersistenceAdapter = adapter
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if adapter.gets != 0 || adapter.saves != 0 {
+		t.Errorf("Expected no loads or saves for untouched attributes but got %d loads and %d saves", adapter.gets, adapter.saves)
+	}
+
+	router := &IntentRouter{Fallback: func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		_, err := PersistentAttributes(ctx).Get(ctx)
+		return err
+	}}
+	alexa.RequestHandler = router
+	_, err = alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if adapter.gets != 1 || adapter.saves != 0 {
+		t.Errorf("Expected 1 load and no saves for read only attributes but got %d loads and %d saves", adapter.gets, adapter.saves)
+	}
+}
+
+func TestPersistentAttributesKeys(t *testing.T) {
+	request := createRecipeRequest()
+	request.Context.System.Device.DeviceID = "device1"
+	adapter := NewMemoryPersistenceAdapter()
+	ctx := context.Background()
+
+	router := &IntentRouter{Fallback: func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		PersistentAttributes(ctx).Set(map[string]interface{}{"saved": true})
+		return nil
+	}}
+	alexa := getAlexaWithHandler(router)
+	alexa.PersistenceAdapter = adapter
+	alexa.PersistenceKey = DeviceIDKey
+	_, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	attributes, _ := adapter.GetAttributes(ctx, "device1")
+	if attributes["saved"] != true {
+		t.Error("Expected attributes to be saved under the device ID.")
+	}
+
+	alexa.PersistenceKey = PersonIDKey
+	_, err = alexa.ProcessRequest(ctx, request)
+	if err == nil {
+		t.Error("Expected ProcessRequest to fail for a request without a person ID but no err was returned.")
+	}
+}
+
+func TestFilePersistenceAdapter(t *testing.T) {
+	adapter, err := NewFilePersistenceAdapter(t.TempDir())
+	if err != nil {
+		t.Fatal("Error creating adapter.", err)
+	}
+	ctx := context.Background()
+	key := "amzn1.ask.account.[unique-value-here]/with+unsafe=chars"
+
+	attributes, err := adapter.GetAttributes(ctx, key)
+	if err != nil || attributes != nil {
+		t.Errorf("Expected no attributes for a new key but got %v, err %v", attributes, err)
+	}
+
+	err = adapter.SaveAttributes(ctx, key, map[string]interface{}{"name": "snowball"})
+	if err != nil {
+		t.Fatal("Error saving attributes.", err)
+	}
+	attributes, err = adapter.GetAttributes(ctx, key)
+	if err != nil || attributes["name"] != "snowball" {
+		t.Errorf("Expected name to be snowball but got %v, err %v", attributes, err)
+	}
+
+	err = adapter.DeleteAttributes(ctx, key)
+	if err != nil {
+		t.Fatal("Error deleting attributes.", err)
+	}
+	attributes, err = adapter.GetAttributes(ctx, key)
+	if err != nil || attributes != nil {
+		t.Errorf("Expected no attributes after delete but got %v, err %v", attributes, err)
+	}
+}
+
+func TestMemoryPersistenceAdapterZeroValue(t *testing.T) {
+	adapter := &MemoryPersistenceAdapter{}
+	ctx := context.Background()
+
+	err := adapter.SaveAttributes(ctx, "key", map[string]interface{}{"name": "snowball"})
+	if err != nil {
+		t.Fatal("Error saving attributes.", err)
+	}
+	attributes, err := adapter.GetAttributes(ctx, "key")
+	if err != nil || attributes["name"] != "snowball" {
+		t.Errorf("Expected name to be snowball but got %v, err %v", attributes, err)
+	}
+}
+
+func TestFilePersistenceAdapterNoDir(t *testing.T) {
+	_, err := NewFilePersistenceAdapter("")
+	if !errors.Is(err, ErrNoPersistenceDir) {
+		t.Errorf("Expected ErrNoPersistenceDir from NewFilePersistenceAdapter but got %v", err)
+	}
+
+	adapter := &FilePersistenceAdapter{}
+	ctx := context.Background()
+	_, err = adapter.GetAttributes(ctx, "key")
+	if !errors.Is(err, ErrNoPersistenceDir) {
+		t.Errorf("Expected ErrNoPersistenceDir from GetAttributes but got %v", err)
+	}
+	err = adapter.SaveAttributes(ctx, "key", map[string]interface{}{})
+	if !errors.Is(err, ErrNoPersistenceDir) {
+		t.Errorf("Expected ErrNoPersistenceDir from SaveAttributes but got %v", err)
+	}
+	err = adapter.DeleteAttributes(ctx, "key")
+	if !errors.Is(err, ErrNoPersistenceDir) {
+		t.Errorf("Expected ErrNoPersistenceDir from DeleteAttributes but got %v", err)
+	}
+}
+
+type countingPersistenceAdapter struct {
+	PersistenceAdapter
+	gets  int
+	saves int
+}
+
+func (a *countingPersistenceAdapter) GetAttributes(ctx context.Context, key string) (map[string]interface{}, error) {
+	a.gets++
+	return a.PersistenceAdapter.GetAttributes(ctx, key)
+}
+
+func (a *countingPersistenceAdapter) SaveAttributes(ctx context.Context, key string, attributes map[string]interface{}) error {
+	a.saves++
+	return a.PersistenceAdapter.SaveAttributes(ctx, key, attributes)
+}
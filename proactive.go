@@ -0,0 +1,270 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Stages of the Proactive Events API. Events sent to the development stage
+// only reach users of the skill in development.
+const (
+	ProactiveStageDevelopment = "development"
+	ProactiveStageLive        = "live"
+)
+
+// Audience types of a proactive event.
+const (
+	AudienceUnicast   = "Unicast"
+	AudienceMulticast = "Multicast"
+)
+
+const (
+	proactiveTimeLayout = "2006-01-02T15:04:05.000Z"
+	proactiveMinExpiry  = 5 * time.Minute
+	proactiveMaxExpiry  = 24 * time.Hour
+	proactiveEventsPath = "/v1/proactiveEvents"
+)
+
+// ErrInvalidProactiveEvent reports that a ProactiveEvent cannot be sent.
+var ErrInvalidProactiveEvent = errors.New("invalid proactive event")
+
+// ProactiveEventPayload is the payload of a proactive event schema.
+type ProactiveEventPayload interface {
+	// ProactiveEventName returns the schema name, such as "AMAZON.MessageAlert.Activated".
+	ProactiveEventName() string
+}
+
+// ProactiveEvent is a notification sent to users outside of a session.
+type ProactiveEvent struct {
+	// ReferenceID uniquely identifies the event. Events with the same
+	// ReferenceID replace earlier ones.
+	ReferenceID string
+	// Timestamp defaults to the time the event is sent.
+	Timestamp time.Time
+	// ExpiryTime must be between 5 minutes and 24 hours after Timestamp.
+	// It defaults to 24 hours after Timestamp.
+	ExpiryTime time.Time
+	Payload    ProactiveEventPayload
+	// LocalizedAttributes provide the values of "localizedattribute:" references
+	// in the payload for each locale, and must include a "locale" key.
+	LocalizedAttributes []map[string]string
+	Audience            ProactiveAudience
+}
+
+// ProactiveAudience selects the users that receive a proactive event.
+type ProactiveAudience struct {
+	Type string
+	// UserID is the user of a Unicast event.
+	UserID string
+}
+
+// UnicastAudience sends an event to the user with userID.
+func UnicastAudience(userID string) ProactiveAudience {
+	return ProactiveAudience{Type: AudienceUnicast, UserID: userID}
+}
+
+// MulticastAudience sends an event to every user subscribed to it.
+func MulticastAudience() ProactiveAudience {
+	return ProactiveAudience{Type: AudienceMulticast}
+}
+
+// MarshalJSON encodes the event in the format of the Proactive Events API.
+// It returns an error wrapping ErrInvalidProactiveEvent if Payload is nil.
+func (e ProactiveEvent) MarshalJSON() ([]byte, error) {
+	if e.Payload == nil {
+		return nil, invalidProactiveEvent("payload must be set")
+	}
+	type event struct {
+		Name    string                `json:"name"`
+		Payload ProactiveEventPayload `json:"payload"`
+	}
+	type audience struct {
+		Type    string            `json:"type"`
+		Payload map[string]string `json:"payload"`
+	}
+	aud := audience{Type: e.Audience.Type, Payload: map[string]string{}}
+	if e.Audience.Type == AudienceUnicast {
+		aud.Payload["user"] = e.Audience.UserID
+	}
+	localized := e.LocalizedAttributes
+	if localized == nil {
+		localized = []map[string]string{}
+	}
+	return json.Marshal(struct {
+		Timestamp           string              `json:"timestamp"`
+		ReferenceID         string              `json:"referenceId"`
+		ExpiryTime          string              `json:"expiryTime"`
+		Event               event               `json:"event"`
+		LocalizedAttributes []map[string]string `json:"localizedAttributes"`
+		RelevantAudience    audience            `json:"relevantAudience"`
+	}{
+		Timestamp:           e.Timestamp.UTC().Format(proactiveTimeLayout),
+		ReferenceID:         e.ReferenceID,
+		ExpiryTime:          e.ExpiryTime.UTC().Format(proactiveTimeLayout),
+		Event:               event{Name: e.Payload.ProactiveEventName(), Payload: e.Payload},
+		LocalizedAttributes: localized,
+		RelevantAudience:    aud,
+	})
+}
+
+// validate checks the event, returning an error wrapping ErrInvalidProactiveEvent.
+func (e *ProactiveEvent) validate() error {
+	if e.ReferenceID == "" {
+		return invalidProactiveEvent("reference ID must be set")
+	}
+	if e.Payload == nil {
+		return invalidProactiveEvent("payload must be set")
+	}
+	switch e.Audience.Type {
+	case AudienceUnicast:
+		if e.Audience.UserID == "" {
+			return invalidProactiveEvent("unicast audience requires a user ID")
+		}
+	case AudienceMulticast:
+	default:
+		return invalidProactiveEvent("audience must be Unicast or Multicast")
+	}
+	expiry := e.ExpiryTime.Sub(e.Timestamp)
+	if expiry < proactiveMinExpiry || expiry > proactiveMaxExpiry {
+		return invalidProactiveEvent("expiry time must be between 5 minutes and 24 hours after the timestamp")
+	}
+	for _, attributes := range e.LocalizedAttributes {
+		if attributes["locale"] == "" {
+			return invalidProactiveEvent("localized attributes require a locale")
+		}
+	}
+	return nil
+}
+
+func invalidProactiveEvent(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidProactiveEvent, reason)
+}
+
+// ProactiveEventsClient sends proactive events, authenticated with a token
+// for the ScopeProactiveEvents scope.
+type ProactiveEventsClient struct {
+	TokenProvider AccessTokenProvider
+	// Stage is ProactiveStageDevelopment or ProactiveStageLive. Defaults to
+	// ProactiveStageDevelopment.
+	Stage string
+	// APIEndpoint is the Alexa API endpoint for the region of the users.
+	// Defaults to DefaultAPIEndpoint.
+	APIEndpoint string
+	HTTPClient  *http.Client
+	// Clock sets the default event timestamp. Defaults to the system clock.
+	Clock Clock
+}
+
+// NewProactiveEventsClient creates a ProactiveEventsClient sending events to
+// stage with tokens from a new LWATokenProvider for the skill's client credentials.
+func NewProactiveEventsClient(clientID, clientSecret, stage string) *ProactiveEventsClient {
+	return &ProactiveEventsClient{
+		TokenProvider: NewLWATokenProvider(clientID, clientSecret, ScopeProactiveEvents),
+		Stage:         stage,
+	}
+}
+
+// Send validates and sends event. A zero Timestamp or ExpiryTime is replaced
+// by its default in the event sent, without modifying event, so an event can
+// be sent again later.
+func (c *ProactiveEventsClient) Send(ctx context.Context, event *ProactiveEvent) error {
+	e := *event
+	if e.Timestamp.IsZero() {
+		e.Timestamp = c.now()
+	}
+	if e.ExpiryTime.IsZero() {
+		e.ExpiryTime = e.Timestamp.Add(proactiveMaxExpiry)
+	}
+	err := e.validate()
+	if err != nil {
+		return err
+	}
+
+	path := proactiveEventsPath
+	switch c.Stage {
+	case "", ProactiveStageDevelopment:
+		path += "/stages/development"
+	case ProactiveStageLive:
+	default:
+		return errors.New("unknown proactive events stage " + c.Stage)
+	}
+
+	client, err := newTokenServiceClient(ctx, c.TokenProvider, c.APIEndpoint, c.HTTPClient)
+	if err != nil {
+		return err
+	}
+	return client.do(ctx, http.MethodPost, path, &e, nil)
+}
+
+func (c *ProactiveEventsClient) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock.Now()
+	}
+	return time.Now()
+}
+
+// MessageAlertActivated is the AMAZON.MessageAlert.Activated schema, which
+// notifies users of new messages.
+type MessageAlertActivated struct {
+	State struct {
+		// Status is "UNREAD" or "FLAGGED".
+		Status string `json:"status"`
+		// Freshness is "NEW" or "OVERDUE".
+		Freshness string `json:"freshness,omitempty"`
+	} `json:"state"`
+	MessageGroup struct {
+		Creator struct {
+			Name string `json:"name"`
+		} `json:"creator"`
+		Count int `json:"count"`
+		// Urgency is "URGENT" or empty.
+		Urgency string `json:"urgency,omitempty"`
+	} `json:"messageGroup"`
+}
+
+// ProactiveEventName implements ProactiveEventPayload.
+func (MessageAlertActivated) ProactiveEventName() string {
+	return "AMAZON.MessageAlert.Activated"
+}
+
+// OrderStatusUpdated is the AMAZON.OrderStatus.Updated schema, which notifies
+// users of changes to an order.
+type OrderStatusUpdated struct {
+	State struct {
+		// Status is an order status such as "ORDER_SHIPPED" or "ORDER_DELIVERED".
+		Status          string `json:"status"`
+		EnterTimestamp  string `json:"enterTimestamp,omitempty"`
+		DeliveryDetails *struct {
+			ExpectedArrival string `json:"expectedArrival"`
+		} `json:"deliveryDetails,omitempty"`
+	} `json:"state"`
+	Order struct {
+		Seller struct {
+			Name string `json:"name"`
+		} `json:"seller"`
+	} `json:"order"`
+}
+
+// ProactiveEventName implements ProactiveEventPayload.
+func (OrderStatusUpdated) ProactiveEventName() string {
+	return "AMAZON.OrderStatus.Updated"
+}
+
+// WeatherAlertActivated is the AMAZON.WeatherAlert.Activated schema, which
+// notifies users of weather alerts.
+type WeatherAlertActivated struct {
+	WeatherAlert struct {
+		Source string `json:"source"`
+		// AlertType is a weather alert type such as "TORNADO" or "HURRICANE".
+		AlertType string `json:"alertType"`
+	} `json:"weatherAlert"`
+}
+
+// ProactiveEventName implements ProactiveEventPayload.
+func (WeatherAlertActivated) ProactiveEventName() string {
+	return "AMAZON.WeatherAlert.Activated"
+}
@@ -0,0 +1,178 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type staticTokenProvider string
+
+func (p staticTokenProvider) AccessToken(ctx context.Context) (string, error) {
+	return string(p), nil
+}
+
+func newMessageAlert() *MessageAlertActivated {
+	alert := &MessageAlertActivated{}
+	alert.State.Status = "UNREAD"
+	alert.State.Freshness = "NEW"
+	alert.MessageGroup.Creator.Name = "Andy"
+	alert.MessageGroup.Count = 5
+	return alert
+}
+
+func TestProactiveEventsClientSend(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer lwa-token" {
+			t.Errorf("Expected LWA bearer token but was %s", auth)
+		}
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	timestamp := time.Date(2024, 6, 18, 22, 10, 1, 0, time.UTC)
+	client := &ProactiveEventsClient{TokenProvider: staticTokenProvider("lwa-token"), APIEndpoint: server.URL}
+	event := &ProactiveEvent{
+		ReferenceID: "ref-1",
+		Timestamp:   timestamp,
+		ExpiryTime:  timestamp.Add(time.Hour),
+		Payload:     newMessageAlert(),
+		Audience:    UnicastAudience("amzn1.ask.account.1"),
+	}
+	err := client.Send(context.Background(), event)
+	if err != nil {
+		t.Fatal("Error sending event. " + err.Error())
+	}
+	if path != "/v1/proactiveEvents/stages/development" {
+		t.Errorf("Expected the development stage but was %s", path)
+	}
+	expected := `{"timestamp":"2024-06-18T22:10:01.000Z","referenceId":"ref-1","expiryTime":"2024-06-18T23:10:01.000Z",` +
+		`"event":{"name":"AMAZON.MessageAlert.Activated","payload":{"state":{"status":"UNREAD","freshness":"NEW"},"messageGroup":{"creator":{"name":"Andy"},"count":5}}},` +
+		`"localizedAttributes":[],"relevantAudience":{"type":"Unicast","payload":{"user":"amzn1.ask.account.1"}}}`
+	if body != expected {
+		t.Errorf("Expected %s but was %s", expected, body)
+	}
+
+	client.Stage = ProactiveStageLive
+	weather := &WeatherAlertActivated{}
+	weather.WeatherAlert.Source = "localizedattribute:source"
+	weather.WeatherAlert.AlertType = "TORNADO"
+	event = &ProactiveEvent{
+		ReferenceID:         "ref-2",
+		Payload:             weather,
+		LocalizedAttributes: []map[string]string{{"locale": "en-US", "source": "National Weather Service"}},
+		Audience:            MulticastAudience(),
+	}
+	err = client.Send(context.Background(), event)
+	if err != nil {
+		t.Fatal("Error sending event. " + err.Error())
+	}
+	if path != "/v1/proactiveEvents" {
+		t.Errorf("Expected the live stage but was %s", path)
+	}
+	var sent struct {
+		Timestamp        string `json:"timestamp"`
+		ExpiryTime       string `json:"expiryTime"`
+		RelevantAudience struct {
+			Type    string            `json:"type"`
+			Payload map[string]string `json:"payload"`
+		} `json:"relevantAudience"`
+	}
+	json.Unmarshal([]byte(body), &sent)
+	if sent.RelevantAudience.Type != AudienceMulticast || len(sent.RelevantAudience.Payload) != 0 {
+		t.Errorf("Unexpected audience %+v", sent.RelevantAudience)
+	}
+	sentTimestamp, _ := time.Parse(proactiveTimeLayout, sent.Timestamp)
+	sentExpiry, _ := time.Parse(proactiveTimeLayout, sent.ExpiryTime)
+	if !sentExpiry.Equal(sentTimestamp.Add(24 * time.Hour)) {
+		t.Errorf("Expected the expiry time to default to 24 hours but was %s", sent.ExpiryTime)
+	}
+	if !event.Timestamp.IsZero() || !event.ExpiryTime.IsZero() {
+		t.Errorf("Expected the defaults not to be written to the event but was %s, %s", event.Timestamp, event.ExpiryTime)
+	}
+}
+
+func TestProactiveEventValidation(t *testing.T) {
+	timestamp := time.Date(2024, 6, 18, 22, 10, 1, 0, time.UTC)
+	valid := func() *ProactiveEvent {
+		return &ProactiveEvent{ReferenceID: "ref", Timestamp: timestamp, ExpiryTime: timestamp.Add(time.Hour), Payload: newMessageAlert(), Audience: MulticastAudience()}
+	}
+	tests := map[string]func(e *ProactiveEvent){
+		"no reference ID": func(e *ProactiveEvent) { e.ReferenceID = "" },
+		"no payload":      func(e *ProactiveEvent) { e.Payload = nil },
+		"no audience":     func(e *ProactiveEvent) { e.Audience = ProactiveAudience{} },
+		"unicast no user": func(e *ProactiveEvent) { e.Audience = UnicastAudience("") },
+		"short expiry":    func(e *ProactiveEvent) { e.ExpiryTime = timestamp.Add(time.Minute) },
+		"long expiry":     func(e *ProactiveEvent) { e.ExpiryTime = timestamp.Add(25 * time.Hour) },
+		"no locale":       func(e *ProactiveEvent) { e.LocalizedAttributes = []map[string]string{{"source": "NWS"}} },
+	}
+	client := &ProactiveEventsClient{TokenProvider: staticTokenProvider("lwa-token"), APIEndpoint: "http://127.0.0.1:0"}
+	for name, modify := range tests {
+		event := valid()
+		modify(event)
+		err := client.Send(context.Background(), event)
+		if !errors.Is(err, ErrInvalidProactiveEvent) {
+			t.Errorf("%s: expected ErrInvalidProactiveEvent but was %v", name, err)
+		}
+	}
+}
+
+func TestProactiveEventMarshalJSON(t *testing.T) {
+	timestamp := time.Date(2024, 6, 18, 22, 10, 1, 0, time.UTC)
+	event := ProactiveEvent{ReferenceID: "ref", Timestamp: timestamp, ExpiryTime: timestamp.Add(time.Hour), Payload: newMessageAlert(), Audience: MulticastAudience()}
+
+	byValue, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal("Error marshaling event. " + err.Error())
+	}
+	byPointer, err := json.Marshal(&event)
+	if err != nil {
+		t.Fatal("Error marshaling event. " + err.Error())
+	}
+	if string(byValue) != string(byPointer) {
+		t.Errorf("Expected the same JSON for a value and a pointer but was %s and %s", byValue, byPointer)
+	}
+
+	_, err = json.Marshal(&ProactiveEvent{ReferenceID: "ref", Audience: MulticastAudience()})
+	if !errors.Is(err, ErrInvalidProactiveEvent) {
+		t.Errorf("Expected ErrInvalidProactiveEvent for an event without a payload but was %v", err)
+	}
+}
+
+func TestProactiveEventsClientWithLWA(t *testing.T) {
+	var tokenCalls int
+	lwa := newLWAServer(t, &tokenCalls)
+	defer lwa.Close()
+
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := NewProactiveEventsClient("client", "secret", ProactiveStageDevelopment)
+	client.TokenProvider.(*LWATokenProvider).TokenURL = lwa.URL
+	client.APIEndpoint = server.URL
+	for i := 0; i < 2; i++ {
+		err := client.Send(context.Background(), &ProactiveEvent{ReferenceID: "ref", Payload: newMessageAlert(), Audience: MulticastAudience()})
+		if err != nil {
+			t.Fatal("Error sending event. " + err.Error())
+		}
+	}
+	if tokenCalls != 1 {
+		t.Errorf("Expected the LWA token to be reused, calls %d", tokenCalls)
+	}
+	if len(auths) != 2 || auths[1] != "Bearer token-1" {
+		t.Errorf("Unexpected authorization headers %v", auths)
+	}
+}
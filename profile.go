@@ -0,0 +1,94 @@
+package alexa
+
+import (
+	"context"
+)
+
+// Permissions requested with SetAskForPermissionsConsentCard to read the customer profile.
+const (
+	PermissionReadName         = "alexa::profile:name:read"
+	PermissionReadGivenName    = "alexa::profile:given_name:read"
+	PermissionReadEmail        = "alexa::profile:email:read"
+	PermissionReadMobileNumber = "alexa::profile:mobile_number:read"
+)
+
+// PhoneNumber is a mobile number from the customer profile.
+type PhoneNumber struct {
+	CountryCode string `json:"countryCode"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// ProfileClient reads the profile of the account owner, or of the recognized
+// speaker, from the Customer Profile API. Responses are cached for the request
+// being processed, so handlers reading the same field do not fetch it again.
+// Methods return ErrPermissionNotGranted if the user has not granted the
+// matching permission.
+type ProfileClient struct {
+	client *serviceClient
+}
+
+// NewProfileClient creates a ProfileClient using the API endpoint and access
+// token of the request Context.
+func NewProfileClient(c *Context) *ProfileClient {
+	return NewServiceClientFactory(c).ProfileClient()
+}
+
+// Name returns the full name of the account owner. It requires the
+// PermissionReadName permission.
+func (p *ProfileClient) Name(ctx context.Context) (string, error) {
+	return p.getString(ctx, "/v2/accounts/~current/settings/Profile.name")
+}
+
+// GivenName returns the given name of the account owner. It requires the
+// PermissionReadGivenName permission.
+func (p *ProfileClient) GivenName(ctx context.Context) (string, error) {
+	return p.getString(ctx, "/v2/accounts/~current/settings/Profile.givenName")
+}
+
+// Email returns the email address of the account owner. It requires the
+// PermissionReadEmail permission.
+func (p *ProfileClient) Email(ctx context.Context) (string, error) {
+	return p.getString(ctx, "/v2/accounts/~current/settings/Profile.email")
+}
+
+// MobileNumber returns the mobile number of the account owner. It requires
+// the PermissionReadMobileNumber permission.
+func (p *ProfileClient) MobileNumber(ctx context.Context) (*PhoneNumber, error) {
+	return p.getPhoneNumber(ctx, "/v2/accounts/~current/settings/Profile.mobileNumber")
+}
+
+// PersonName returns the full name of the recognized speaker. It requires
+// the PermissionReadName permission and a Context with a Person.
+func (p *ProfileClient) PersonName(ctx context.Context) (string, error) {
+	return p.getString(ctx, "/v2/persons/~current/profile/name")
+}
+
+// PersonGivenName returns the given name of the recognized speaker. It
+// requires the PermissionReadGivenName permission and a Context with a Person.
+func (p *ProfileClient) PersonGivenName(ctx context.Context) (string, error) {
+	return p.getString(ctx, "/v2/persons/~current/profile/givenName")
+}
+
+// PersonMobileNumber returns the mobile number of the recognized speaker. It
+// requires the PermissionReadMobileNumber permission and a Context with a Person.
+func (p *ProfileClient) PersonMobileNumber(ctx context.Context) (*PhoneNumber, error) {
+	return p.getPhoneNumber(ctx, "/v2/persons/~current/profile/mobileNumber")
+}
+
+func (p *ProfileClient) getString(ctx context.Context, path string) (string, error) {
+	var s string
+	err := p.client.getCached(ctx, path, &s)
+	if err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+func (p *ProfileClient) getPhoneNumber(ctx context.Context, path string) (*PhoneNumber, error) {
+	n := &PhoneNumber{}
+	err := p.client.getCached(ctx, path, n)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
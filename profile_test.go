@@ -0,0 +1,129 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newProfileServer(t *testing.T, calls *int32) *httptest.Server {
+	responses := map[string]string{
+		"/v2/accounts/~current/settings/Profile.name":         `"Jane Doe"`,
+		"/v2/accounts/~current/settings/Profile.givenName":    `"Jane"`,
+		"/v2/accounts/~current/settings/Profile.email":        `"jane@example.com"`,
+		"/v2/accounts/~current/settings/Profile.mobileNumber": `{"countryCode":"+1","phoneNumber":"5555550100"}`,
+		"/v2/persons/~current/profile/name":                   `"John Doe"`,
+		"/v2/persons/~current/profile/givenName":              `"John"`,
+		"/v2/persons/~current/profile/mobileNumber":           `{"countryCode":"+1","phoneNumber":"5555550101"}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestProfileClient(t *testing.T) {
+	var calls int32
+	server := newProfileServer(t, &calls)
+	defer server.Close()
+
+	ctx := context.Background()
+	p := NewProfileClient(newServiceContext(server.URL))
+	for _, test := range []struct {
+		get      func(context.Context) (string, error)
+		expected string
+	}{
+		{p.Name, "Jane Doe"},
+		{p.GivenName, "Jane"},
+		{p.Email, "jane@example.com"},
+		{p.PersonName, "John Doe"},
+		{p.PersonGivenName, "John"},
+	} {
+		value, err := test.get(ctx)
+		if err != nil {
+			t.Fatal("Error reading profile. " + err.Error())
+		}
+		if value != test.expected {
+			t.Errorf("Expected %s but was %s", test.expected, value)
+		}
+	}
+
+	number, err := p.MobileNumber(ctx)
+	if err != nil {
+		t.Fatal("Error reading mobile number. " + err.Error())
+	}
+	if *number != (PhoneNumber{CountryCode: "+1", PhoneNumber: "5555550100"}) {
+		t.Errorf("Unexpected mobile number %+v", *number)
+	}
+	number, err = p.PersonMobileNumber(ctx)
+	if err != nil {
+		t.Fatal("Error reading person mobile number. " + err.Error())
+	}
+	if number.PhoneNumber != "5555550101" {
+		t.Errorf("Unexpected person mobile number %+v", *number)
+	}
+}
+
+func TestProfileClientCachesPerRequest(t *testing.T) {
+	var calls int32
+	server := newProfileServer(t, &calls)
+	defer server.Close()
+
+	readName := func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		name, err := NewProfileClient(aContext).GivenName(ctx)
+		if err != nil {
+			return err
+		}
+		response.SetOutputText("Hello " + name)
+		return nil
+	}
+	alexa := getAlexaWithHandler(&IntentRouter{Fallback: readName})
+	alexa.RequestInterceptors = []RequestInterceptor{func(ctx context.Context, requestEnv *RequestEnvelope, response *Response) (bool, error) {
+		return false, readName(ctx, requestEnv.Request, requestEnv.Session, requestEnv.Context, response)
+	}}
+
+	request := createRecipeRequest()
+	request.Context.System.APIEndpoint = server.URL
+	request.Context.System.APIAccessToken = "test-api-token"
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call to the profile API but was %d", calls)
+	}
+
+	request = createRecipeRequest()
+	request.Context.System.APIEndpoint = server.URL
+	request.Context.System.APIAccessToken = "test-api-token"
+	_, err = alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if calls != 2 {
+		t.Errorf("Expected a new request to call the profile API again, calls %d", calls)
+	}
+}
+
+func TestProfileClientPermissionNotGranted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := NewProfileClient(newServiceContext(server.URL)).Email(context.Background())
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected ErrPermissionNotGranted but was %v", err)
+	}
+}
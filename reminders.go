@@ -0,0 +1,325 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PermissionRemindersReadWrite is requested with SetAskForPermissionsConsentCard
+// to create and manage reminders.
+const PermissionRemindersReadWrite = "alexa::alerts:reminders:skill:readwrite"
+
+// Reminder trigger types and push notification statuses.
+const (
+	TriggerScheduledAbsolute = "SCHEDULED_ABSOLUTE"
+	TriggerScheduledRelative = "SCHEDULED_RELATIVE"
+	PushNotificationEnabled  = "ENABLED"
+	PushNotificationDisabled = "DISABLED"
+)
+
+const (
+	reminderTimeLayout = "2006-01-02T15:04:05.000"
+	remindersPath      = "/v1/alerts/reminders"
+)
+
+// ErrInvalidReminder reports that a ReminderBuilder could not build a valid reminder.
+var ErrInvalidReminder = errors.New("invalid reminder")
+
+// Reminder is the payload used to create or update a reminder.
+type Reminder struct {
+	RequestTime      string           `json:"requestTime"`
+	Trigger          ReminderTrigger  `json:"trigger"`
+	AlertInfo        AlertInfo        `json:"alertInfo"`
+	PushNotification PushNotification `json:"pushNotification"`
+}
+
+// ReminderTrigger describes when a reminder is delivered.
+type ReminderTrigger struct {
+	Type            string              `json:"type"`
+	ScheduledTime   string              `json:"scheduledTime,omitempty"`
+	OffsetInSeconds int                 `json:"offsetInSeconds,omitempty"`
+	TimeZoneID      string              `json:"timeZoneId,omitempty"`
+	Recurrence      *ReminderRecurrence `json:"recurrence,omitempty"`
+}
+
+// ReminderRecurrence repeats a SCHEDULED_ABSOLUTE reminder using RFC 5545
+// recurrence rules such as "FREQ=WEEKLY;BYDAY=MO;BYHOUR=9;BYMINUTE=0".
+type ReminderRecurrence struct {
+	StartDateTime   string   `json:"startDateTime,omitempty"`
+	EndDateTime     string   `json:"endDateTime,omitempty"`
+	RecurrenceRules []string `json:"recurrenceRules,omitempty"`
+}
+
+// AlertInfo contains what Alexa says when a reminder is delivered.
+type AlertInfo struct {
+	SpokenInfo struct {
+		Content []SpokenContent `json:"content"`
+	} `json:"spokenInfo"`
+}
+
+// SpokenContent is the text or SSML spoken for a locale.
+type SpokenContent struct {
+	Locale string `json:"locale"`
+	Text   string `json:"text,omitempty"`
+	SSML   string `json:"ssml,omitempty"`
+}
+
+// PushNotification controls whether a reminder is also sent to the Alexa app.
+type PushNotification struct {
+	Status string `json:"status"`
+}
+
+// ReminderResponse describes a reminder stored by Alexa.
+type ReminderResponse struct {
+	AlertToken       string           `json:"alertToken"`
+	CreatedTime      string           `json:"createdTime"`
+	UpdatedTime      string           `json:"updatedTime"`
+	Status           string           `json:"status"`
+	Version          string           `json:"version"`
+	Href             string           `json:"href"`
+	Trigger          ReminderTrigger  `json:"trigger"`
+	AlertInfo        AlertInfo        `json:"alertInfo"`
+	PushNotification PushNotification `json:"pushNotification"`
+}
+
+// ReminderList is a page of the reminders created by the skill for the user.
+type ReminderList struct {
+	TotalCount json.Number        `json:"totalCount"`
+	Alerts     []ReminderResponse `json:"alerts"`
+	Links      struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// ReminderBuilder builds a Reminder, validating it in Build.
+type ReminderBuilder struct {
+	reminder    Reminder
+	requestTime time.Time
+	triggers    int
+	relative    time.Duration
+	scheduled   time.Time
+	recurrence  *ReminderRecurrence
+	start, end  time.Time
+}
+
+// NewReminder creates a ReminderBuilder. Push notifications are enabled by default.
+func NewReminder() *ReminderBuilder {
+	b := &ReminderBuilder{}
+	b.reminder.PushNotification.Status = PushNotificationEnabled
+	return b
+}
+
+// RequestTime sets the time the reminder was requested. It defaults to the
+// time Build is called.
+func (b *ReminderBuilder) RequestTime(t time.Time) *ReminderBuilder {
+	b.requestTime = t
+	return b
+}
+
+// At delivers the reminder at the wall clock time of t in timeZoneID, such as
+// "America/Los_Angeles", or in the time zone of the device if timeZoneID is
+// empty. Build checks timeZoneID against the system time zone database, so
+// programs running where it is missing must import time/tzdata.
+func (b *ReminderBuilder) At(t time.Time, timeZoneID string) *ReminderBuilder {
+	b.triggers++
+	b.reminder.Trigger.Type = TriggerScheduledAbsolute
+	b.reminder.Trigger.TimeZoneID = timeZoneID
+	b.scheduled = t
+	return b
+}
+
+// In delivers the reminder after offset, which is rounded down to whole seconds.
+func (b *ReminderBuilder) In(offset time.Duration) *ReminderBuilder {
+	b.triggers++
+	b.reminder.Trigger.Type = TriggerScheduledRelative
+	b.relative = offset
+	return b
+}
+
+// Recurring repeats a reminder set with At using the RFC 5545 recurrence
+// rules. The start and end times are optional and ignored if zero.
+func (b *ReminderBuilder) Recurring(start, end time.Time, rules ...string) *ReminderBuilder {
+	b.recurrence = &ReminderRecurrence{RecurrenceRules: rules}
+	b.start = start
+	b.end = end
+	return b
+}
+
+// Text adds the text spoken for locale.
+func (b *ReminderBuilder) Text(locale, text string) *ReminderBuilder {
+	b.reminder.AlertInfo.SpokenInfo.Content = append(b.reminder.AlertInfo.SpokenInfo.Content, SpokenContent{Locale: locale, Text: text})
+	return b
+}
+
+// SSML adds the SSML spoken for locale, with text shown in the Alexa app.
+func (b *ReminderBuilder) SSML(locale, ssml, text string) *ReminderBuilder {
+	b.reminder.AlertInfo.SpokenInfo.Content = append(b.reminder.AlertInfo.SpokenInfo.Content, SpokenContent{Locale: locale, SSML: ssml, Text: text})
+	return b
+}
+
+// PushNotification enables or disables sending the reminder to the Alexa app.
+func (b *ReminderBuilder) PushNotification(enabled bool) *ReminderBuilder {
+	b.reminder.PushNotification.Status = PushNotificationDisabled
+	if enabled {
+		b.reminder.PushNotification.Status = PushNotificationEnabled
+	}
+	return b
+}
+
+// Build validates the reminder and returns it, or an error wrapping
+// ErrInvalidReminder. An error for a time zone that cannot be loaded also
+// wraps ErrUnknownTimeZone.
+func (b *ReminderBuilder) Build() (*Reminder, error) {
+	r := b.reminder
+	r.Trigger.Recurrence = nil
+
+	switch {
+	case b.triggers == 0:
+		return nil, invalidReminder("a trigger must be set with At or In")
+	case b.triggers > 1:
+		return nil, invalidReminder("only one of At or In may be set")
+	}
+
+	if r.Trigger.Type == TriggerScheduledRelative {
+		if b.relative < time.Second {
+			return nil, invalidReminder("relative offset must be at least one second")
+		}
+		if b.recurrence != nil {
+			return nil, invalidReminder("recurrence requires an absolute trigger")
+		}
+		r.Trigger.OffsetInSeconds = int(b.relative / time.Second)
+	} else {
+		if b.scheduled.IsZero() {
+			return nil, invalidReminder("scheduled time must be set")
+		}
+		if r.Trigger.TimeZoneID != "" {
+			_, err := time.LoadLocation(r.Trigger.TimeZoneID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w %s: %v", ErrInvalidReminder, ErrUnknownTimeZone, r.Trigger.TimeZoneID, err)
+			}
+		}
+		r.Trigger.ScheduledTime = b.scheduled.Format(reminderTimeLayout)
+	}
+
+	if b.recurrence != nil {
+		if len(b.recurrence.RecurrenceRules) == 0 {
+			return nil, invalidReminder("recurrence requires at least one rule")
+		}
+		for _, rule := range b.recurrence.RecurrenceRules {
+			if !strings.HasPrefix(rule, "FREQ=") {
+				return nil, invalidReminder("recurrence rule " + rule + " must start with FREQ=")
+			}
+		}
+		if !b.start.IsZero() && !b.end.IsZero() && !b.end.After(b.start) {
+			return nil, invalidReminder("recurrence must end after it starts")
+		}
+		recurrence := &ReminderRecurrence{RecurrenceRules: append([]string(nil), b.recurrence.RecurrenceRules...)}
+		if !b.start.IsZero() {
+			recurrence.StartDateTime = b.start.Format(reminderTimeLayout)
+		}
+		if !b.end.IsZero() {
+			recurrence.EndDateTime = b.end.Format(reminderTimeLayout)
+		}
+		r.Trigger.Recurrence = recurrence
+	}
+
+	content := r.AlertInfo.SpokenInfo.Content
+	if len(content) == 0 {
+		return nil, invalidReminder("spoken content must be set with Text or SSML")
+	}
+	locales := make(map[string]bool)
+	for _, c := range content {
+		if c.Locale == "" {
+			return nil, invalidReminder("spoken content requires a locale")
+		}
+		if c.Text == "" && c.SSML == "" {
+			return nil, invalidReminder("spoken content for " + c.Locale + " is empty")
+		}
+		if locales[c.Locale] {
+			return nil, invalidReminder("spoken content for " + c.Locale + " is set more than once")
+		}
+		locales[c.Locale] = true
+	}
+	r.AlertInfo.SpokenInfo.Content = append([]SpokenContent(nil), content...)
+
+	requestTime := b.requestTime
+	if requestTime.IsZero() {
+		requestTime = time.Now().UTC()
+	}
+	r.RequestTime = requestTime.Format(reminderTimeLayout)
+	return &r, nil
+}
+
+func invalidReminder(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidReminder, reason)
+}
+
+// RemindersClient creates and manages reminders with the Reminders API. It
+// can be used while handling any request with an API access token, including
+// skill events and messages sent with a SkillMessagingClient, not only during
+// a session. Methods return ErrPermissionNotGranted if the user has not
+// granted PermissionRemindersReadWrite.
+type RemindersClient struct {
+	client *serviceClient
+}
+
+// NewRemindersClient creates a RemindersClient using the API endpoint and
+// access token of the request Context.
+func NewRemindersClient(c *Context) *RemindersClient {
+	return NewServiceClientFactory(c).RemindersClient()
+}
+
+// Create creates a reminder.
+func (r *RemindersClient) Create(ctx context.Context, reminder *Reminder) (*ReminderResponse, error) {
+	resp := &ReminderResponse{}
+	err := r.client.do(ctx, http.MethodPost, remindersPath, reminder, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Get returns the reminder with alertToken.
+func (r *RemindersClient) Get(ctx context.Context, alertToken string) (*ReminderResponse, error) {
+	resp := &ReminderResponse{}
+	err := r.client.do(ctx, http.MethodGet, reminderPath(alertToken), nil, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// List returns the reminders created by the skill for the user.
+func (r *RemindersClient) List(ctx context.Context) (*ReminderList, error) {
+	list := &ReminderList{}
+	err := r.client.do(ctx, http.MethodGet, remindersPath, nil, list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Update replaces the reminder with alertToken.
+func (r *RemindersClient) Update(ctx context.Context, alertToken string, reminder *Reminder) (*ReminderResponse, error) {
+	resp := &ReminderResponse{}
+	err := r.client.do(ctx, http.MethodPut, reminderPath(alertToken), reminder, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Delete deletes the reminder with alertToken.
+func (r *RemindersClient) Delete(ctx context.Context, alertToken string) error {
+	return r.client.do(ctx, http.MethodDelete, reminderPath(alertToken), nil, nil)
+}
+
+func reminderPath(alertToken string) string {
+	return remindersPath + "/" + url.PathEscape(alertToken)
+}
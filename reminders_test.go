@@ -0,0 +1,163 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReminderBuilderAbsolute(t *testing.T) {
+	requestTime := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	reminder, err := NewReminder().
+		RequestTime(requestTime).
+		At(time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), "America/Los_Angeles").
+		Recurring(start, time.Time{}, "FREQ=WEEKLY;BYDAY=MO;BYHOUR=9;BYMINUTE=0").
+		Text("en-US", "Take out the trash").
+		SSML("de-DE", "<speak>Müll rausbringen</speak>", "Müll rausbringen").
+		PushNotification(false).
+		Build()
+	if err != nil {
+		t.Fatal("Error building reminder. " + err.Error())
+	}
+
+	b, err := json.Marshal(reminder)
+	if err != nil {
+		t.Fatal("Error marshalling reminder. " + err.Error())
+	}
+	expected := `{"requestTime":"2024-03-01T08:30:00.000","trigger":{"type":"SCHEDULED_ABSOLUTE","scheduledTime":"2024-03-04T09:00:00.000","timeZoneId":"America/Los_Angeles","recurrence":{"startDateTime":"2024-03-04T00:00:00.000","recurrenceRules":["FREQ=WEEKLY;BYDAY=MO;BYHOUR=9;BYMINUTE=0"]}},"alertInfo":{"spokenInfo":{"content":[{"locale":"en-US","text":"Take out the trash"},{"locale":"de-DE","text":"Müll rausbringen","ssml":"\u003cspeak\u003eMüll rausbringen\u003c/speak\u003e"}]}},"pushNotification":{"status":"DISABLED"}}`
+	if string(b) != expected {
+		t.Errorf("Expected %s but was %s", expected, b)
+	}
+}
+
+func TestReminderBuilderRelative(t *testing.T) {
+	reminder, err := NewReminder().In(90*time.Minute).Text("en-US", "Check the oven").Build()
+	if err != nil {
+		t.Fatal("Error building reminder. " + err.Error())
+	}
+	if reminder.Trigger.Type != TriggerScheduledRelative || reminder.Trigger.OffsetInSeconds != 5400 {
+		t.Errorf("Unexpected trigger %+v", reminder.Trigger)
+	}
+	if reminder.PushNotification.Status != PushNotificationEnabled {
+		t.Errorf("Expected push notifications to be enabled by default but was %s", reminder.PushNotification.Status)
+	}
+	if reminder.RequestTime == "" {
+		t.Error("Expected requestTime to default to the current time.")
+	}
+}
+
+func TestReminderBuilderValidation(t *testing.T) {
+	at := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	tests := map[string]*ReminderBuilder{
+		"no trigger":          NewReminder().Text("en-US", "Hello"),
+		"two triggers":        NewReminder().At(at, "").In(time.Hour).Text("en-US", "Hello"),
+		"short offset":        NewReminder().In(time.Millisecond).Text("en-US", "Hello"),
+		"zero scheduled time": NewReminder().At(time.Time{}, "").Text("en-US", "Hello"),
+		"unknown time zone":   NewReminder().At(at, "Mars/Olympus_Mons").Text("en-US", "Hello"),
+		"relative recurrence": NewReminder().In(time.Hour).Recurring(time.Time{}, time.Time{}, "FREQ=DAILY").Text("en-US", "Hello"),
+		"no recurrence rules": NewReminder().At(at, "").Recurring(time.Time{}, time.Time{}).Text("en-US", "Hello"),
+		"invalid rule":        NewReminder().At(at, "").Recurring(time.Time{}, time.Time{}, "BYDAY=MO").Text("en-US", "Hello"),
+		"end before start":    NewReminder().At(at, "").Recurring(at, at.Add(-time.Hour), "FREQ=DAILY").Text("en-US", "Hello"),
+		"no content":          NewReminder().In(time.Hour),
+		"no locale":           NewReminder().In(time.Hour).Text("", "Hello"),
+		"empty content":       NewReminder().In(time.Hour).Text("en-US", ""),
+		"duplicate locale":    NewReminder().In(time.Hour).Text("en-US", "Hello").Text("en-US", "Hi"),
+	}
+	for name, builder := range tests {
+		_, err := builder.Build()
+		if !errors.Is(err, ErrInvalidReminder) {
+			t.Errorf("%s: expected ErrInvalidReminder but was %v", name, err)
+		}
+	}
+}
+
+func TestReminderBuilderUnknownTimeZone(t *testing.T) {
+	_, err := NewReminder().At(time.Now().Add(time.Hour), "Mars/Olympus_Mons").Text("en-US", "Hello").Build()
+	if !errors.Is(err, ErrInvalidReminder) || !errors.Is(err, ErrUnknownTimeZone) {
+		t.Errorf("Expected ErrInvalidReminder and ErrUnknownTimeZone but was %v", err)
+	}
+}
+
+func TestRemindersClient(t *testing.T) {
+	var created Reminder
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/alerts/reminders", "PUT /v1/alerts/reminders/token-1":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"alertToken":"token-1","createdTime":"2024-03-01T08:30:00.000Z","status":"ON","version":"1","href":"/v1/alerts/reminders/token-1"}`))
+		case "GET /v1/alerts/reminders/token-1":
+			w.Write([]byte(`{"alertToken":"token-1","status":"ON","trigger":{"type":"SCHEDULED_RELATIVE","offsetInSeconds":3600}}`))
+		case "GET /v1/alerts/reminders":
+			w.Write([]byte(`{"totalCount":"1","alerts":[{"alertToken":"token-1","status":"ON"}],"links":{}}`))
+		case "DELETE /v1/alerts/reminders/token-1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewRemindersClient(newServiceContext(server.URL))
+	reminder, err := NewReminder().In(time.Hour).Text("en-US", "Stretch").Build()
+	if err != nil {
+		t.Fatal("Error building reminder. " + err.Error())
+	}
+
+	resp, err := client.Create(ctx, reminder)
+	if err != nil {
+		t.Fatal("Error creating reminder. " + err.Error())
+	}
+	if resp.AlertToken != "token-1" || resp.Status != "ON" {
+		t.Errorf("Unexpected reminder response %+v", resp)
+	}
+	if created.Trigger.OffsetInSeconds != 3600 || created.AlertInfo.SpokenInfo.Content[0].Text != "Stretch" {
+		t.Errorf("Unexpected reminder sent %+v", created)
+	}
+
+	resp, err = client.Get(ctx, "token-1")
+	if err != nil {
+		t.Fatal("Error getting reminder. " + err.Error())
+	}
+	if resp.Trigger.Type != TriggerScheduledRelative {
+		t.Errorf("Unexpected trigger %+v", resp.Trigger)
+	}
+
+	list, err := client.List(ctx)
+	if err != nil {
+		t.Fatal("Error listing reminders. " + err.Error())
+	}
+	if list.TotalCount != "1" || len(list.Alerts) != 1 {
+		t.Errorf("Unexpected reminder list %+v", list)
+	}
+
+	_, err = client.Update(ctx, "token-1", reminder)
+	if err != nil {
+		t.Fatal("Error updating reminder. " + err.Error())
+	}
+	err = client.Delete(ctx, "token-1")
+	if err != nil {
+		t.Fatal("Error deleting reminder. " + err.Error())
+	}
+}
+
+func TestRemindersClientPermissionNotGranted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := NewRemindersClient(newServiceContext(server.URL)).List(context.Background())
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected ErrPermissionNotGranted but was %v", err)
+	}
+}
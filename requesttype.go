@@ -0,0 +1,230 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Request types sent by Alexa in addition to LaunchRequest, IntentRequest and
+// SessionEndedRequest.
+const (
+	AudioPlayerPlaybackStarted        = "AudioPlayer.PlaybackStarted"
+	AudioPlayerPlaybackFinished       = "AudioPlayer.PlaybackFinished"
+	AudioPlayerPlaybackStopped        = "AudioPlayer.PlaybackStopped"
+	AudioPlayerPlaybackNearlyFinished = "AudioPlayer.PlaybackNearlyFinished"
+	AudioPlayerPlaybackFailed         = "AudioPlayer.PlaybackFailed"
+	PlaybackControllerNextCommand     = "PlaybackController.NextCommandIssued"
+	PlaybackControllerPauseCommand    = "PlaybackController.PauseCommandIssued"
+	PlaybackControllerPlayCommand     = "PlaybackController.PlayCommandIssued"
+	PlaybackControllerPreviousCommand = "PlaybackController.PreviousCommandIssued"
+	APLUserEvent                      = "Alexa.Presentation.APL.UserEvent"
+	ConnectionsResponse               = "Connections.Response"
+	SystemExceptionEncountered        = "System.ExceptionEncountered"
+	MessagingMessageReceived          = "Messaging.MessageReceived"
+)
+
+// ErrPayloadUnavailable reports that a Request was not decoded from JSON, so
+// its type specific payload is not available.
+var ErrPayloadUnavailable = errors.New("request payload is not available")
+
+// UnhandledRequestHandler may be implemented by a RequestHandler to handle
+// requests of a type that has no handler registered with HandleRequestType.
+type UnhandledRequestHandler interface {
+	OnUnhandled(context.Context, *Request, *Session, *Context, *Response) error
+}
+
+// HandleRequestType registers handler for requests of requestType. The
+// requestType is either an exact type such as "AudioPlayer.PlaybackStarted"
+// or a namespace wildcard such as "AudioPlayer.*". Exact types take
+// precedence over wildcards. LaunchRequest, IntentRequest and
+// SessionEndedRequest are always passed to the RequestHandler.
+func (alexa *Alexa) HandleRequestType(requestType string, handler HandlerFunc) {
+	if alexa.requestTypeHandlers == nil {
+		alexa.requestTypeHandlers = make(map[string]HandlerFunc)
+	}
+	alexa.requestTypeHandlers[requestType] = handler
+}
+
+// dispatchRequestType calls the handler registered for the request type, or
+// OnUnhandled if the RequestHandler implements UnhandledRequestHandler.
+func (alexa *Alexa) dispatchRequestType(ctx context.Context, request *Request, session *Session, context *Context, response *Response) error {
+	if handler := alexa.requestTypeHandler(request.Type); handler != nil {
+		return handler(ctx, request, session, context, response)
+	}
+	return alexa.dispatchUnhandled(ctx, request, session, context, response)
+}
+
+// dispatchUnhandled calls OnUnhandled if the RequestHandler implements
+// UnhandledRequestHandler, and otherwise logs and ignores the request.
+func (alexa *Alexa) dispatchUnhandled(ctx context.Context, request *Request, session *Session, context *Context, response *Response) error {
+	if unhandled, ok := alexa.RequestHandler.(UnhandledRequestHandler); ok {
+		return unhandled.OnUnhandled(ctx, request, session, context, response)
+	}
+
+	LoggerFromContext(ctx).Warn("No handler registered for request type.")
+	return nil
+}
+
+// requestTypeHandler returns the handler registered for requestType, or nil.
+func (alexa *Alexa) requestTypeHandler(requestType string) HandlerFunc {
+	if handler, ok := alexa.requestTypeHandlers[requestType]; ok {
+		return handler
+	}
+	if i := strings.LastIndex(requestType, "."); i >= 0 {
+		if handler, ok := alexa.requestTypeHandlers[requestType[:i]+".*"]; ok {
+			return handler
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the common Request fields and retains the JSON so that
+// type specific payloads can be decoded with DecodePayload.
+func (r *Request) UnmarshalJSON(b []byte) error {
+	type request Request
+	var req request
+	err := json.Unmarshal(b, &req)
+	if err != nil {
+		return err
+	}
+	*r = Request(req)
+	r.raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// DecodePayload decodes the full request JSON into v.
+func (r *Request) DecodePayload(v interface{}) error {
+	if r.raw == nil {
+		return ErrPayloadUnavailable
+	}
+	return json.Unmarshal(r.raw, v)
+}
+
+// decodeTypedPayload checks the request type against the namespace prefix and decodes the payload into v.
+func (r *Request) decodeTypedPayload(prefix string, v interface{}) error {
+	if !strings.HasPrefix(r.Type, prefix) {
+		return errors.New("request type " + r.Type + " is not a " + prefix + " request")
+	}
+	return r.DecodePayload(v)
+}
+
+// RequestError describes an error reported in a request.
+type RequestError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// PlaybackState describes the state of the AudioPlayer.
+type PlaybackState struct {
+	Token                string `json:"token"`
+	OffsetInMilliseconds int    `json:"offsetInMilliseconds"`
+	PlayerActivity       string `json:"playerActivity"`
+}
+
+// AudioPlayerRequest contains the payload of AudioPlayer.* requests.
+type AudioPlayerRequest struct {
+	Token                string         `json:"token"`
+	OffsetInMilliseconds int            `json:"offsetInMilliseconds"`
+	Error                *RequestError  `json:"error,omitempty"`
+	CurrentPlaybackState *PlaybackState `json:"currentPlaybackState,omitempty"`
+}
+
+// AudioPlayer decodes the payload of an AudioPlayer.* request.
+func (r *Request) AudioPlayer() (*AudioPlayerRequest, error) {
+	p := &AudioPlayerRequest{}
+	err := r.decodeTypedPayload("AudioPlayer.", p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// PlaybackControllerRequest contains the payload of PlaybackController.*
+// requests, which carry no data beyond the request type.
+type PlaybackControllerRequest struct {
+	Type string `json:"type"`
+}
+
+// PlaybackController decodes the payload of a PlaybackController.* request.
+func (r *Request) PlaybackController() (*PlaybackControllerRequest, error) {
+	p := &PlaybackControllerRequest{}
+	err := r.decodeTypedPayload("PlaybackController.", p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// APLUserEventRequest contains the payload of Alexa.Presentation.APL.UserEvent requests.
+type APLUserEventRequest struct {
+	Token      string                 `json:"token"`
+	Arguments  []interface{}          `json:"arguments"`
+	Source     map[string]interface{} `json:"source"`
+	Components map[string]interface{} `json:"components"`
+}
+
+// APLUserEvent decodes the payload of an Alexa.Presentation.APL.UserEvent request.
+func (r *Request) APLUserEvent() (*APLUserEventRequest, error) {
+	p := &APLUserEventRequest{}
+	err := r.decodeTypedPayload(APLUserEvent, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ConnectionsResponseRequest contains the payload of Connections.Response requests.
+type ConnectionsResponseRequest struct {
+	Name   string `json:"name"`
+	Status struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+	Payload json.RawMessage `json:"payload"`
+	Token   string          `json:"token"`
+}
+
+// ConnectionsResponse decodes the payload of a Connections.Response request.
+func (r *Request) ConnectionsResponse() (*ConnectionsResponseRequest, error) {
+	p := &ConnectionsResponseRequest{}
+	err := r.decodeTypedPayload(ConnectionsResponse, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SystemExceptionRequest contains the payload of System.ExceptionEncountered requests.
+type SystemExceptionRequest struct {
+	Error RequestError `json:"error"`
+	Cause struct {
+		RequestID string `json:"requestId"`
+	} `json:"cause"`
+}
+
+// SystemException decodes the payload of a System.ExceptionEncountered request.
+func (r *Request) SystemException() (*SystemExceptionRequest, error) {
+	p := &SystemExceptionRequest{}
+	err := r.decodeTypedPayload(SystemExceptionEncountered, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// MessageReceivedRequest contains the payload of Messaging.MessageReceived requests.
+type MessageReceivedRequest struct {
+	Message map[string]interface{} `json:"message"`
+}
+
+// MessageReceived decodes the payload of a Messaging.MessageReceived request.
+func (r *Request) MessageReceived() (*MessageReceivedRequest, error) {
+	p := &MessageReceivedRequest{}
+	err := r.decodeTypedPayload(MessagingMessageReceived, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,121 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+const audioPlayerFailedString = `{
+	"type": "AudioPlayer.PlaybackFailed",
+	"requestId": "amzn1.echo-api.request.abc123",
+	"timestamp": "2019-10-04T17:36:04Z",
+	"locale": "en-US",
+	"token": "track1",
+	"error": {
+		"type": "MEDIA_ERROR_SERVICE_UNAVAILABLE",
+		"message": "Stream unavailable"
+	},
+	"currentPlaybackState": {
+		"token": "track1",
+		"offsetInMilliseconds": 1200,
+		"playerActivity": "PLAYING"
+	}
+}`
+
+func TestRequestTypePayloads(t *testing.T) {
+	var request Request
+	err := json.Unmarshal([]byte(audioPlayerFailedString), &request)
+	if err != nil {
+		t.Fatal("Error unmarshaling request.", err)
+	}
+	if request.RequestID != "amzn1.echo-api.request.abc123" {
+		t.Error("Expected RequestID to be amzn1.echo-api.request.abc123 but was", request.RequestID)
+	}
+
+	payload, err := request.AudioPlayer()
+	if err != nil {
+		t.Fatal("Error decoding AudioPlayer payload.", err)
+	}
+	if payload.Token != "track1" {
+		t.Error("Expected Token to be track1 but was", payload.Token)
+	}
+	if payload.Error == nil || payload.Error.Type != "MEDIA_ERROR_SERVICE_UNAVAILABLE" {
+		t.Error("Expected Error.Type to be MEDIA_ERROR_SERVICE_UNAVAILABLE but was", payload.Error)
+	}
+	if payload.CurrentPlaybackState == nil || payload.CurrentPlaybackState.OffsetInMilliseconds != 1200 {
+		t.Error("Expected CurrentPlaybackState.OffsetInMilliseconds to be 1200 but was", payload.CurrentPlaybackState)
+	}
+
+	_, err = request.APLUserEvent()
+	if err == nil {
+		t.Error("Expected APLUserEvent to fail for an AudioPlayer request but no err was returned.")
+	}
+
+	_, err = (&Request{Type: AudioPlayerPlaybackStarted}).AudioPlayer()
+	if err != ErrPayloadUnavailable {
+		t.Error("Expected ErrPayloadUnavailable for a Request not decoded from JSON but got", err)
+	}
+}
+
+func TestHandleRequestType(t *testing.T) {
+	request := createRecipeRequest()
+	ctx := context.Background()
+
+	alexa := getAlexa()
+	var called string
+	alexa.HandleRequestType("AudioPlayer.*", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		called = "wildcard"
+		return nil
+	})
+	alexa.HandleRequestType(AudioPlayerPlaybackFailed, func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		called = "exact"
+		return nil
+	})
+
+	request.Request.Type = AudioPlayerPlaybackFailed
+	_, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if called != "exact" {
+		t.Error("Expected the exact request type handler to be called but was", called)
+	}
+
+	request.Request.Type = AudioPlayerPlaybackStarted
+	_, err = alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if called != "wildcard" {
+		t.Error("Expected the wildcard request type handler to be called but was", called)
+	}
+}
+
+func TestOnUnhandled(t *testing.T) {
+	request := createRecipeRequest()
+	request.Request.Type = SystemExceptionEncountered
+
+	handler := &unhandledRequestHandler{}
+	alexa := getAlexaWithHandler(handler)
+	_, err := alexa.ProcessRequest(context.Background(), request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if handler.OnUnhandledType != SystemExceptionEncountered {
+		t.Error("Expected OnUnhandled to be called for System.ExceptionEncountered but was called for", handler.OnUnhandledType)
+	}
+	if handler.OnIntentCalled {
+		t.Error("OnIntent was called for a System.ExceptionEncountered request.")
+	}
+}
+
+type unhandledRequestHandler struct {
+	emptyRequestHandler
+	OnUnhandledType string
+}
+
+func (h *unhandledRequestHandler) OnUnhandled(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+	h.OnUnhandledType = request.Type
+	return nil
+}
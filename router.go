@@ -0,0 +1,156 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Built-in intent names defined by Amazon.
+const (
+	CancelIntent       = "AMAZON.CancelIntent"
+	FallbackIntent     = "AMAZON.FallbackIntent"
+	HelpIntent         = "AMAZON.HelpIntent"
+	LoopOffIntent      = "AMAZON.LoopOffIntent"
+	LoopOnIntent       = "AMAZON.LoopOnIntent"
+	NavigateHomeIntent = "AMAZON.NavigateHomeIntent"
+	NextIntent         = "AMAZON.NextIntent"
+	NoIntent           = "AMAZON.NoIntent"
+	PauseIntent        = "AMAZON.PauseIntent"
+	PreviousIntent     = "AMAZON.PreviousIntent"
+	RepeatIntent       = "AMAZON.RepeatIntent"
+	ResumeIntent       = "AMAZON.ResumeIntent"
+	ShuffleOffIntent   = "AMAZON.ShuffleOffIntent"
+	ShuffleOnIntent    = "AMAZON.ShuffleOnIntent"
+	StartOverIntent    = "AMAZON.StartOverIntent"
+	StopIntent         = "AMAZON.StopIntent"
+	YesIntent          = "AMAZON.YesIntent"
+)
+
+// Dialog states reported in Request.DialogState.
+const (
+	DialogStateStarted    = "STARTED"
+	DialogStateInProgress = "IN_PROGRESS"
+	DialogStateCompleted  = "COMPLETED"
+)
+
+// ErrNoMatchingHandler reports that no handler was registered for a request.
+var ErrNoMatchingHandler = errors.New("no handler matched the request")
+
+// HandlerFunc is a function that handles a single Alexa request.
+type HandlerFunc func(context.Context, *Request, *Session, *Context, *Response) error
+
+// RequestMatcher reports whether a request should be handled by the
+// associated HandlerFunc.
+type RequestMatcher func(*Request) bool
+
+// IntentIs matches IntentRequests for the intent name.
+func IntentIs(name string) RequestMatcher {
+	return func(r *Request) bool {
+		return r.Type == intentRequestName && r.Intent.Name == name
+	}
+}
+
+// DialogStateIs matches requests with the dialog state.
+func DialogStateIs(state string) RequestMatcher {
+	return func(r *Request) bool {
+		return r.DialogState == state
+	}
+}
+
+// AllOf matches requests matched by every one of matchers.
+func AllOf(matchers ...RequestMatcher) RequestMatcher {
+	return func(r *Request) bool {
+		for _, m := range matchers {
+			if !m(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// IntentRouter is a RequestHandler that dispatches IntentRequests to the
+// HandlerFunc registered for the intent. The zero value is ready to use.
+//
+// Handlers registered with HandleMatch are checked first in the order they
+// were registered, then handlers registered by intent name with
+// HandleIntent, and finally Fallback. If nothing matches, OnIntent returns an
+// error wrapping ErrNoMatchingHandler.
+type IntentRouter struct {
+	// SessionStarted is called for new sessions. Optional.
+	SessionStarted HandlerFunc
+	// Launch is called for LaunchRequests.
+	Launch HandlerFunc
+	// SessionEnded is called for SessionEndedRequests. Optional.
+	SessionEnded HandlerFunc
+	// Fallback is called for IntentRequests that match no other handler.
+	Fallback HandlerFunc
+
+	intents map[string]HandlerFunc
+	matches []routerMatch
+}
+
+type routerMatch struct {
+	matcher RequestMatcher
+	handler HandlerFunc
+}
+
+// HandleIntent registers handler for the intent name.
+func (r *IntentRouter) HandleIntent(name string, handler HandlerFunc) {
+	if r.intents == nil {
+		r.intents = make(map[string]HandlerFunc)
+	}
+	r.intents[name] = handler
+}
+
+// HandleMatch registers handler for IntentRequests matched by matcher.
+func (r *IntentRouter) HandleMatch(matcher RequestMatcher, handler HandlerFunc) {
+	r.matches = append(r.matches, routerMatch{matcher: matcher, handler: handler})
+}
+
+// OnSessionStarted calls SessionStarted if set.
+func (r *IntentRouter) OnSessionStarted(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+	if r.SessionStarted == nil {
+		return nil
+	}
+	return r.SessionStarted(ctx, request, session, aContext, response)
+}
+
+// OnLaunch calls Launch, or returns an error wrapping ErrNoMatchingHandler if it is not set.
+func (r *IntentRouter) OnLaunch(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+	if r.Launch == nil {
+		return fmt.Errorf("%w: %s", ErrNoMatchingHandler, launchRequestName)
+	}
+	return r.Launch(ctx, request, session, aContext, response)
+}
+
+// OnIntent dispatches the request to the matching handler.
+func (r *IntentRouter) OnIntent(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+	handler := r.handler(request)
+	if handler == nil {
+		return fmt.Errorf("%w: intent %s", ErrNoMatchingHandler, request.Intent.Name)
+	}
+	return handler(ctx, request, session, aContext, response)
+}
+
+// OnSessionEnded calls SessionEnded if set.
+func (r *IntentRouter) OnSessionEnded(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+	if r.SessionEnded == nil {
+		return nil
+	}
+	return r.SessionEnded(ctx, request, session, aContext, response)
+}
+
+// handler returns the HandlerFunc for request, or nil if none matches.
+func (r *IntentRouter) handler(request *Request) HandlerFunc {
+	for _, m := range r.matches {
+		if m.matcher(request) {
+			return m.handler
+		}
+	}
+	if h, ok := r.intents[request.Intent.Name]; ok {
+		return h
+	}
+	return r.Fallback
+}
@@ -0,0 +1,111 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIntentRouterDispatchesByName(t *testing.T) {
+	request := createRecipeRequest()
+
+	router := &IntentRouter{}
+	router.HandleIntent("RecipeIntent", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Recipe")
+		return nil
+	})
+	router.HandleIntent(HelpIntent, func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Help")
+		return nil
+	})
+
+	alexa := getAlexaWithHandler(router)
+	ctx := context.Background()
+	responseEnv, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Recipe" {
+		t.Errorf("Response Text should have been Recipe but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+
+	request.Request.Intent.Name = HelpIntent
+	responseEnv, err = alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Help" {
+		t.Errorf("Response Text should have been Help but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+}
+
+func TestIntentRouterMatchers(t *testing.T) {
+	request := createRecipeRequest()
+	request.Request.DialogState = DialogStateStarted
+
+	router := &IntentRouter{}
+	router.HandleIntent("RecipeIntent", func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Name")
+		return nil
+	})
+	router.HandleMatch(AllOf(IntentIs("RecipeIntent"), DialogStateIs(DialogStateStarted)), func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Started")
+		return nil
+	})
+
+	alexa := getAlexaWithHandler(router)
+	ctx := context.Background()
+	responseEnv, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Started" {
+		t.Errorf("Response Text should have been Started but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+
+	request.Request.DialogState = DialogStateCompleted
+	responseEnv, err = alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Name" {
+		t.Errorf("Response Text should have been Name but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+}
+
+func TestIntentRouterFallbackAndNoMatch(t *testing.T) {
+	request := createRecipeRequest()
+
+	router := &IntentRouter{}
+	alexa := getAlexaWithHandler(router)
+	ctx := context.Background()
+	_, err := alexa.ProcessRequest(ctx, request)
+	if !errors.Is(err, ErrNoMatchingHandler) {
+		t.Error("Expected ProcessRequest to fail with ErrNoMatchingHandler but got", err)
+	}
+
+	request.Request.Type = launchRequestName
+	_, err = alexa.ProcessRequest(ctx, request)
+	if !errors.Is(err, ErrNoMatchingHandler) {
+		t.Error("Expected ProcessRequest to fail with ErrNoMatchingHandler for an unset Launch handler but got", err)
+	}
+
+	request.Request.Type = intentRequestName
+	router.Fallback = func(ctx context.Context, request *Request, session *Session, aContext *Context, response *Response) error {
+		response.SetOutputText("Fallback")
+		return nil
+	}
+	responseEnv, err := alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Fatal("Error processing request. " + err.Error())
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Fallback" {
+		t.Errorf("Response Text should have been Fallback but was %s", responseEnv.Response.OutputSpeech.Text)
+	}
+
+	request.Request.Type = sessionEndedRequestName
+	_, err = alexa.ProcessRequest(ctx, request)
+	if err != nil {
+		t.Error("Expected an unset SessionEnded handler to be ignored but got error", err)
+	}
+}
@@ -0,0 +1,380 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIEndpoint is the North America endpoint of the Alexa APIs, used by
+// clients that are not created from a request Context.
+const DefaultAPIEndpoint = "https://api.amazonalexa.com"
+
+const (
+	defaultServiceMaxRetries   = 2
+	defaultServiceRetryBackoff = 100 * time.Millisecond
+	maxServiceRetryDelay       = 5 * time.Second
+)
+
+// ErrNoAPIAccess reports that the request Context does not contain the API
+// endpoint and access token needed to call the Alexa service APIs.
+var ErrNoAPIAccess = errors.New("request context does not contain an API endpoint and access token")
+
+// ErrPermissionNotGranted reports that the user has not granted the skill
+// permission to the requested data. A ServiceError for a 403 response, or for
+// a 401 response from the Reminders or Timers API, matches it with errors.Is.
+// Skills usually respond with SetAskForPermissionsConsentCard.
+var ErrPermissionNotGranted = errors.New("permission not granted")
+
+// ServiceError is returned when an Alexa service API responds with an error status.
+type ServiceError struct {
+	StatusCode int
+	// Code is the error code reported by Amazon, such as "INVALID_DIRECTIVE".
+	Code    string
+	Message string
+
+	// unauthorizedIsPermission is set for APIs that report a missing
+	// permission with a 401 rather than a 403 response.
+	unauthorizedIsPermission bool
+}
+
+func (e *ServiceError) Error() string {
+	s := "alexa service returned " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+	if e.Code != "" {
+		s += " " + e.Code
+	}
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	return s
+}
+
+// Is reports whether e is a 403 response when target is
+// ErrPermissionNotGranted. A 401 response matches only if it is from the
+// Reminders or Timers API, which report missing permissions that way; from
+// the other APIs it means the access token is not valid.
+func (e *ServiceError) Is(target error) bool {
+	if target != ErrPermissionNotGranted {
+		return false
+	}
+	return e.StatusCode == http.StatusForbidden || (e.StatusCode == http.StatusUnauthorized && e.unauthorizedIsPermission)
+}
+
+// ServiceClientFactory creates the clients for the Alexa service APIs, which
+// share its endpoint, access token, HTTP client and retry settings. Requests
+// are retried with exponential backoff after a 429 response, and after a 5xx
+// response if the method is idempotent.
+type ServiceClientFactory struct {
+	APIEndpoint    string
+	APIAccessToken string
+	HTTPClient     *http.Client
+	// MaxRetries is the number of retries after the first attempt. Defaults
+	// to 2, and a negative value disables retries.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, which doubles for
+	// each retry. Defaults to 100ms. A Retry-After header takes precedence.
+	RetryBackoff time.Duration
+
+	deviceID string
+	cache    *serviceCache
+}
+
+// NewServiceClientFactory creates a ServiceClientFactory using the API
+// endpoint, access token and device of the request Context. Clients created
+// by it share the response cache of the request being processed.
+func NewServiceClientFactory(c *Context) *ServiceClientFactory {
+	f := &ServiceClientFactory{}
+	if c != nil {
+		f.APIEndpoint = c.System.APIEndpoint
+		f.APIAccessToken = c.System.APIAccessToken
+		f.deviceID = c.System.Device.DeviceID
+		f.cache = c.cache
+	}
+	if f.cache == nil {
+		f.cache = &serviceCache{}
+	}
+	return f
+}
+
+// newTokenServiceClient creates a serviceClient for APIs called outside of a
+// request, authenticated with a token from tokens.
+func newTokenServiceClient(ctx context.Context, tokens AccessTokenProvider, endpoint string, httpClient *http.Client) (*serviceClient, error) {
+	token, err := tokens.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+	f := &ServiceClientFactory{APIEndpoint: endpoint, APIAccessToken: token, HTTPClient: httpClient, cache: &serviceCache{}}
+	return f.client(), nil
+}
+
+// DirectiveClient creates a DirectiveClient.
+func (f *ServiceClientFactory) DirectiveClient() *DirectiveClient {
+	return &DirectiveClient{client: f.client()}
+}
+
+// AddressClient creates an AddressClient for the device of the request.
+func (f *ServiceClientFactory) AddressClient() *AddressClient {
+	return &AddressClient{client: f.client(), deviceID: f.deviceID}
+}
+
+// ProfileClient creates a ProfileClient.
+func (f *ServiceClientFactory) ProfileClient() *ProfileClient {
+	return &ProfileClient{client: f.client()}
+}
+
+// RemindersClient creates a RemindersClient.
+func (f *ServiceClientFactory) RemindersClient() *RemindersClient {
+	client := f.client()
+	client.unauthorizedIsPermission = true
+	return &RemindersClient{client: client}
+}
+
+// ListsClient creates a ListsClient.
+func (f *ServiceClientFactory) ListsClient() *ListsClient {
+	return &ListsClient{client: f.client()}
+}
+
+// SettingsClient creates a SettingsClient for the device of the request.
+func (f *ServiceClientFactory) SettingsClient() *SettingsClient {
+	return &SettingsClient{client: f.client(), deviceID: f.deviceID}
+}
+
+// MonetizationClient creates a MonetizationClient returning products in locale.
+func (f *ServiceClientFactory) MonetizationClient(locale string) *MonetizationClient {
+	client := f.client()
+	client.language = locale
+	return &MonetizationClient{client: client}
+}
+
+// TimersClient creates a TimersClient.
+func (f *ServiceClientFactory) TimersClient() *TimersClient {
+	client := f.client()
+	client.unauthorizedIsPermission = true
+	return &TimersClient{client: client}
+}
+
+// Do sends a request to an Alexa service API that has no client in this
+// package. The path, such as "/v1/devices/{deviceId}/settings/address", is
+// relative to the APIEndpoint. in, if not nil, is sent as the JSON body and
+// the JSON response is decoded into out, if not nil.
+func (f *ServiceClientFactory) Do(ctx context.Context, method, path string, in, out interface{}) error {
+	return f.client().do(ctx, method, path, in, out)
+}
+
+func (f *ServiceClientFactory) client() *serviceClient {
+	return &serviceClient{factory: f}
+}
+
+// serviceClient calls the Alexa service APIs with the settings of a factory.
+type serviceClient struct {
+	factory *ServiceClientFactory
+	// language, if set, is sent as the Accept-Language header.
+	language string
+	// unauthorizedIsPermission makes a 401 response match
+	// ErrPermissionNotGranted.
+	unauthorizedIsPermission bool
+}
+
+// serviceCache holds the responses of GET requests made while processing a
+// request, so that handlers reading the same data do not fetch it again.
+type serviceCache struct {
+	mu        sync.Mutex
+	responses map[string]json.RawMessage
+}
+
+func (c *serviceCache) get(path string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.responses[path]
+	return b, ok
+}
+
+func (c *serviceCache) put(path string, b json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.responses == nil {
+		c.responses = make(map[string]json.RawMessage)
+	}
+	c.responses[path] = b
+}
+
+// getCached decodes the response to a GET request for path into v, using the
+// cached response if the path was already fetched for this request.
+func (s *serviceClient) getCached(ctx context.Context, path string, v interface{}) error {
+	b, ok := s.factory.cache.get(path)
+	if !ok {
+		err := s.do(ctx, http.MethodGet, path, nil, &b)
+		if err != nil {
+			return err
+		}
+		s.factory.cache.put(path, b)
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, v)
+}
+
+// do sends a request to path with in, if not nil, as the JSON body and decodes
+// the JSON response into out, if not nil. Failed requests are retried as
+// configured by the factory.
+func (s *serviceClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	f := s.factory
+	if f.APIEndpoint == "" || f.APIAccessToken == "" {
+		return ErrNoAPIAccess
+	}
+
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	maxRetries := f.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultServiceMaxRetries
+	}
+	backoff := f.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultServiceRetryBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		b, retryAfter, err := s.send(ctx, method, path, body)
+		if err == nil {
+			if out == nil || len(b) == 0 {
+				return nil
+			}
+			return json.Unmarshal(b, out)
+		}
+		if attempt >= maxRetries || !retryable(method, err) {
+			return err
+		}
+
+		delay := retryDelay(backoff, attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > maxServiceRetryDelay {
+			delay = maxServiceRetryDelay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns backoff doubled for each earlier retry, capped at
+// maxServiceRetryDelay. Doubling stops at the cap so that a large attempt
+// cannot overflow.
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for i := 0; i < attempt && delay < maxServiceRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxServiceRetryDelay {
+		delay = maxServiceRetryDelay
+	}
+	return delay
+}
+
+// send makes a single request, returning the response body, or a
+// ServiceError and the Retry-After delay for an error status.
+func (s *serviceClient) send(ctx context.Context, method, path string, body []byte) ([]byte, time.Duration, error) {
+	f := s.factory
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(f.APIEndpoint, "/")+path, r)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+f.APIAccessToken)
+	req.Header.Set("Accept", "application/json")
+	if s.language != "" {
+		req.Header.Set("Accept-Language", s.language)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := f.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var retryAfter time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		serviceErr := newServiceError(resp.StatusCode, b)
+		serviceErr.unauthorizedIsPermission = s.unauthorizedIsPermission
+		return nil, retryAfter, serviceErr
+	}
+	return b, 0, nil
+}
+
+// newServiceError decodes the code and message of an error response. The
+// Alexa APIs report the code as either "code" or "type".
+func newServiceError(statusCode int, body []byte) *ServiceError {
+	var e struct {
+		Code    string `json:"code"`
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+	json.Unmarshal(body, &e)
+	code := e.Code
+	if code == "" {
+		code = e.Type
+	}
+	return &ServiceError{StatusCode: statusCode, Code: code, Message: e.Message}
+}
+
+// retryable reports whether a request that failed with err may be retried.
+// Requests are retried after a 429 response, which the service did not
+// process, or after a 5xx response to an idempotent method.
+func retryable(method string, err error) bool {
+	var serviceErr *ServiceError
+	if !errors.As(err, &serviceErr) {
+		return false
+	}
+	if serviceErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if serviceErr.StatusCode < 500 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newStatusServer(calls *int32, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(`{"value":"ok"}`))
+		} else {
+			w.Write([]byte(`{"code":"ERROR","message":"failed"}`))
+		}
+	}))
+}
+
+func newTestFactory(endpoint string) *ServiceClientFactory {
+	f := NewServiceClientFactory(newServiceContext(endpoint))
+	f.RetryBackoff = time.Millisecond
+	return f
+}
+
+func TestServiceClientFactoryRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		calls    int32
+		ok       bool
+	}{
+		{"429 then success", http.MethodPost, []int{429, 200}, 2, true},
+		{"5xx GET then success", http.MethodGet, []int{503, 500, 200}, 3, true},
+		{"5xx GET exhausts retries", http.MethodGet, []int{500}, 3, false},
+		{"5xx POST is not retried", http.MethodPost, []int{500, 200}, 1, false},
+		{"4xx is not retried", http.MethodGet, []int{400, 200}, 1, false},
+	}
+	for _, test := range tests {
+		var calls int32
+		server := newStatusServer(&calls, test.statuses...)
+
+		var out struct {
+			Value string `json:"value"`
+		}
+		err := newTestFactory(server.URL).Do(context.Background(), test.method, "/v1/test", nil, &out)
+		server.Close()
+
+		if calls != test.calls {
+			t.Errorf("%s: expected %d calls but was %d", test.name, test.calls, calls)
+		}
+		if test.ok && (err != nil || out.Value != "ok") {
+			t.Errorf("%s: expected success but was %v", test.name, err)
+		}
+		if !test.ok {
+			var serviceErr *ServiceError
+			if !errors.As(err, &serviceErr) || serviceErr.Code != "ERROR" || serviceErr.Message != "failed" {
+				t.Errorf("%s: expected a ServiceError but was %v", test.name, err)
+			}
+		}
+	}
+}
+
+func TestServiceClientFactoryMaxRetries(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, http.StatusTooManyRequests)
+	defer server.Close()
+
+	f := newTestFactory(server.URL)
+	f.MaxRetries = -1
+	f.Do(context.Background(), http.MethodGet, "/v1/test", nil, nil)
+	if calls != 1 {
+		t.Errorf("Expected retries to be disabled, calls %d", calls)
+	}
+
+	calls = 0
+	f.MaxRetries = 4
+	f.Do(context.Background(), http.MethodGet, "/v1/test", nil, nil)
+	if calls != 5 {
+		t.Errorf("Expected 5 calls but was %d", calls)
+	}
+}
+
+func TestServiceClientFactoryCancelDuringBackoff(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	f := newTestFactory(server.URL)
+	f.RetryBackoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := f.Do(ctx, http.MethodGet, "/v1/test", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded but was %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected the backoff to stop when the context is done.")
+	}
+}
+
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServiceClientFactoryHTTPClient(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, http.StatusOK)
+	defer server.Close()
+
+	transport := &recordingTransport{}
+	f := newTestFactory(server.URL)
+	f.HTTPClient = &http.Client{Transport: transport}
+
+	_, err := f.ProfileClient().Name(context.Background())
+	if err == nil {
+		t.Error("Expected an error decoding an object as a name.")
+	}
+	if len(transport.requests) != 1 || transport.requests[0].URL.Path != "/v2/accounts/~current/settings/Profile.name" {
+		t.Errorf("Expected the request to use the injected HTTP client, requests %v", transport.requests)
+	}
+}
+
+func TestServiceErrorIsPermissionNotGranted(t *testing.T) {
+	for status, expected := range map[int]bool{401: false, 403: true, 404: false, 500: false} {
+		err := error(&ServiceError{StatusCode: status})
+		if errors.Is(err, ErrPermissionNotGranted) != expected {
+			t.Errorf("Expected errors.Is(%d, ErrPermissionNotGranted) to be %v", status, expected)
+		}
+	}
+	err := error(&ServiceError{StatusCode: http.StatusUnauthorized, unauthorizedIsPermission: true})
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Error("Expected a 401 from the Reminders or Timers API to match ErrPermissionNotGranted.")
+	}
+}
+
+func TestServiceClientUnauthorized(t *testing.T) {
+	var calls int32
+	server := newStatusServer(&calls, http.StatusUnauthorized)
+	defer server.Close()
+	f := newTestFactory(server.URL)
+	ctx := context.Background()
+
+	_, err := f.AddressClient().Address(ctx)
+	if errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 from the Address API not to match ErrPermissionNotGranted but was %v", err)
+	}
+	_, err = f.ProfileClient().Email(ctx)
+	if errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 from the Profile API not to match ErrPermissionNotGranted but was %v", err)
+	}
+	_, err = f.RemindersClient().List(ctx)
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 from the Reminders API to match ErrPermissionNotGranted but was %v", err)
+	}
+	_, err = f.TimersClient().List(ctx)
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected a 401 from the Timers API to match ErrPermissionNotGranted but was %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		delay   time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{5, 3200 * time.Millisecond},
+		{6, maxServiceRetryDelay},
+		{100, maxServiceRetryDelay},
+		{math.MaxInt32, maxServiceRetryDelay},
+	}
+	for _, test := range tests {
+		if delay := retryDelay(100*time.Millisecond, test.attempt); delay != test.delay {
+			t.Errorf("Expected a delay of %s for attempt %d but was %s", test.delay, test.attempt, delay)
+		}
+	}
+}
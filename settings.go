@@ -0,0 +1,98 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Distance and temperature units returned by the Settings API.
+const (
+	DistanceUnitsMetric       = "METRIC"
+	DistanceUnitsImperial     = "IMPERIAL"
+	TemperatureUnitCelsius    = "CELSIUS"
+	TemperatureUnitFahrenheit = "FAHRENHEIT"
+)
+
+// ErrUnknownTimeZone reports that a time zone could not be loaded, usually
+// because the system has no time zone database.
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// SettingsClient reads the settings of the device that sent a request from
+// the Alexa Settings API. Responses are cached for the request being
+// processed.
+type SettingsClient struct {
+	client   *serviceClient
+	deviceID string
+}
+
+// NewSettingsClient creates a SettingsClient for the device, API endpoint and
+// access token of the request Context.
+func NewSettingsClient(c *Context) *SettingsClient {
+	return NewServiceClientFactory(c).SettingsClient()
+}
+
+// TimeZone returns the IANA time zone of the device, such as "America/Los_Angeles".
+func (s *SettingsClient) TimeZone(ctx context.Context) (string, error) {
+	return s.get(ctx, "System.timeZone")
+}
+
+// DistanceUnits returns DistanceUnitsMetric or DistanceUnitsImperial.
+func (s *SettingsClient) DistanceUnits(ctx context.Context) (string, error) {
+	return s.get(ctx, "System.distanceUnits")
+}
+
+// TemperatureUnit returns TemperatureUnitCelsius or TemperatureUnitFahrenheit.
+func (s *SettingsClient) TemperatureUnit(ctx context.Context) (string, error) {
+	return s.get(ctx, "System.temperatureUnit")
+}
+
+// Location returns the time zone of the device as a time.Location. The time
+// zone database is read from the system, and programs running where it is
+// missing, such as scratch or Lambda images, must import time/tzdata. If the
+// zone cannot be loaded the error matches ErrUnknownTimeZone, and TimeZone
+// still returns the zone ID without another call.
+func (s *SettingsClient) Location(ctx context.Context) (*time.Location, error) {
+	name, err := s.TimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("device time zone is not set")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrUnknownTimeZone, name, err)
+	}
+	return loc, nil
+}
+
+// RequestTime returns the timestamp of request in the time zone of the device.
+func (s *SettingsClient) RequestTime(ctx context.Context, request *Request) (time.Time, error) {
+	if request == nil {
+		return time.Time{}, ErrRequestNil
+	}
+	timestamp, err := time.Parse(time.RFC3339, request.Timestamp)
+	if err != nil {
+		return time.Time{}, errors.New("unable to parse request timestamp.  Err: " + err.Error())
+	}
+	loc, err := s.Location(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return timestamp.In(loc), nil
+}
+
+func (s *SettingsClient) get(ctx context.Context, setting string) (string, error) {
+	if s.deviceID == "" {
+		return "", ErrNoDeviceID
+	}
+	var value string
+	err := s.client.getCached(ctx, "/v2/devices/"+url.PathEscape(s.deviceID)+"/settings/"+setting, &value)
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,122 @@
+package alexa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSettingsServer(t *testing.T, calls *int) *httptest.Server {
+	responses := map[string]string{
+		"/v2/devices/device-1/settings/System.timeZone":        `"America/Los_Angeles"`,
+		"/v2/devices/device-1/settings/System.distanceUnits":   `"IMPERIAL"`,
+		"/v2/devices/device-1/settings/System.temperatureUnit": `"FAHRENHEIT"`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestSettingsClient(t *testing.T) {
+	var calls int
+	server := newSettingsServer(t, &calls)
+	defer server.Close()
+
+	ctx := context.Background()
+	settings := NewSettingsClient(newAddressContext(server.URL))
+
+	zone, err := settings.TimeZone(ctx)
+	if err != nil || zone != "America/Los_Angeles" {
+		t.Errorf("Expected time zone America/Los_Angeles but was %s, %v", zone, err)
+	}
+	units, err := settings.DistanceUnits(ctx)
+	if err != nil || units != DistanceUnitsImperial {
+		t.Errorf("Expected distance units IMPERIAL but was %s, %v", units, err)
+	}
+	unit, err := settings.TemperatureUnit(ctx)
+	if err != nil || unit != TemperatureUnitFahrenheit {
+		t.Errorf("Expected temperature unit FAHRENHEIT but was %s, %v", unit, err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls but was %d", calls)
+	}
+}
+
+func TestSettingsClientRequestTime(t *testing.T) {
+	var calls int
+	server := newSettingsServer(t, &calls)
+	defer server.Close()
+
+	ctx := context.Background()
+	settings := NewSettingsClient(newAddressContext(server.URL))
+	request := &Request{Timestamp: "2024-07-01T16:30:00Z"}
+
+	local, err := settings.RequestTime(ctx, request)
+	if err != nil {
+		t.Fatal("Error getting request time. " + err.Error())
+	}
+	if local.Hour() != 9 || local.Minute() != 30 || local.Location().String() != "America/Los_Angeles" {
+		t.Errorf("Expected 09:30 in America/Los_Angeles but was %s", local)
+	}
+	if !local.Equal(time.Date(2024, 7, 1, 16, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the same instant as the request timestamp but was %s", local)
+	}
+
+	_, err = settings.RequestTime(ctx, request)
+	if err != nil {
+		t.Fatal("Error getting request time. " + err.Error())
+	}
+	if calls != 1 {
+		t.Errorf("Expected the time zone to be cached, calls %d", calls)
+	}
+
+	_, err = settings.RequestTime(ctx, &Request{Timestamp: "yesterday"})
+	if err == nil {
+		t.Error("Expected an error for an invalid timestamp.")
+	}
+}
+
+func TestSettingsClientUnknownTimeZone(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`"Mars/Olympus_Mons"`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	settings := NewSettingsClient(newAddressContext(server.URL))
+
+	_, err := settings.Location(ctx)
+	if !errors.Is(err, ErrUnknownTimeZone) || !strings.Contains(err.Error(), "Mars/Olympus_Mons") {
+		t.Errorf("Expected ErrUnknownTimeZone for Mars/Olympus_Mons but was %v", err)
+	}
+	zone, err := settings.TimeZone(ctx)
+	if err != nil || zone != "Mars/Olympus_Mons" {
+		t.Errorf("Expected the zone ID Mars/Olympus_Mons but was %s, %v", zone, err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the time zone to be cached, calls %d", calls)
+	}
+}
+
+func TestSettingsClientNoDeviceID(t *testing.T) {
+	_, err := NewSettingsClient(newServiceContext("https://api.amazonalexa.com")).TimeZone(context.Background())
+	if err != ErrNoDeviceID {
+		t.Errorf("Expected ErrNoDeviceID but was %v", err)
+	}
+}
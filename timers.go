@@ -0,0 +1,323 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PermissionTimersReadWrite is requested with SetAskForPermissionsConsentCard
+// to create and manage timers. Timer methods return ErrPermissionNotGranted
+// if it has not been granted.
+const PermissionTimersReadWrite = "alexa::alerts:timers:skill:readwrite"
+
+// Operations performed when a timer goes off.
+const (
+	TimerOperationAnnounce   = "ANNOUNCE"
+	TimerOperationNotifyOnly = "NOTIFY_ONLY"
+)
+
+// Timer statuses.
+const (
+	TimerStatusOn     = "ON"
+	TimerStatusPaused = "PAUSED"
+	TimerStatusOff    = "OFF"
+)
+
+const (
+	timersPath       = "/v1/alerts/timers"
+	maxTimerDuration = 24 * time.Hour
+)
+
+// ErrInvalidTimer reports that a TimerRequest cannot be sent.
+var ErrInvalidTimer = errors.New("invalid timer")
+
+// ISODuration is a time.Duration encoded in JSON as an ISO-8601 duration
+// such as "PT1H30M".
+type ISODuration time.Duration
+
+// MarshalJSON encodes d as an ISO-8601 duration.
+func (d ISODuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatISODuration(time.Duration(d)))
+}
+
+// UnmarshalJSON decodes an ISO-8601 duration.
+func (d *ISODuration) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	v, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = ISODuration(v)
+	return nil
+}
+
+// FormatISODuration formats d, rounded to whole seconds, as an ISO-8601
+// duration such as "PT1H30M15S".
+func FormatISODuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		return "-" + FormatISODuration(-d)
+	}
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatInt(int64(d/time.Second), 10) + "S")
+	}
+	return b.String()
+}
+
+// ParseISODuration parses an ISO-8601 duration with day, hour, minute and
+// second components, such as "P1DT2H" or "PT10M30.5S". Each component is a
+// number of digits with an optional fraction, and components must appear at
+// most once and in order.
+func ParseISODuration(s string) (time.Duration, error) {
+	invalid := errors.New("invalid ISO-8601 duration " + s)
+	rest := s
+	negative := strings.HasPrefix(rest, "-")
+	rest = strings.TrimPrefix(rest, "-")
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return 0, invalid
+	}
+	rest = rest[1:]
+
+	var d float64
+	inTime := false
+	last := -1
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return 0, invalid
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		i := strings.IndexAny(rest, "DHMS")
+		if i <= 0 || !isDurationNumber(rest[:i]) {
+			return 0, invalid
+		}
+		value, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, invalid
+		}
+		order := strings.IndexByte("DHMS", rest[i])
+		if order <= last || (order == 0) == inTime {
+			return 0, invalid
+		}
+		last = order
+		unit := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}[order]
+		d += value * float64(unit)
+		rest = rest[i+1:]
+	}
+	if d >= math.MaxInt64 {
+		return 0, invalid
+	}
+	if negative {
+		d = -d
+	}
+	return time.Duration(d), nil
+}
+
+// isDurationNumber reports whether s is digits with an optional fraction.
+func isDurationNumber(s string) bool {
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" || (hasFraction && fraction == "") {
+		return false
+	}
+	for _, c := range whole + fraction {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// TimerRequest describes a timer to create.
+type TimerRequest struct {
+	Duration time.Duration
+	// Label names the timer, such as "pasta".
+	Label string
+	// Operation is TimerOperationAnnounce or TimerOperationNotifyOnly.
+	Operation string
+	// Announcements are spoken when an ANNOUNCE timer goes off.
+	Announcements []SpokenContent
+	// PlayAudible plays the timer sound when it goes off.
+	PlayAudible bool
+}
+
+// NewAnnounceTimer creates a TimerRequest that speaks text in locale when it goes off.
+func NewAnnounceTimer(d time.Duration, label, locale, text string) *TimerRequest {
+	return &TimerRequest{
+		Duration:      d,
+		Label:         label,
+		Operation:     TimerOperationAnnounce,
+		Announcements: []SpokenContent{{Locale: locale, Text: text}},
+		PlayAudible:   true,
+	}
+}
+
+// NewNotifyOnlyTimer creates a TimerRequest that plays the timer sound when it goes off.
+func NewNotifyOnlyTimer(d time.Duration, label string) *TimerRequest {
+	return &TimerRequest{Duration: d, Label: label, Operation: TimerOperationNotifyOnly, PlayAudible: true}
+}
+
+// MarshalJSON encodes the timer in the format of the Timers API.
+func (t *TimerRequest) MarshalJSON() ([]byte, error) {
+	type operation struct {
+		Type           string          `json:"type"`
+		TextToAnnounce []SpokenContent `json:"textToAnnounce,omitempty"`
+	}
+	req := struct {
+		Duration         ISODuration `json:"duration"`
+		TimerLabel       string      `json:"timerLabel,omitempty"`
+		CreationBehavior struct {
+			DisplayExperience struct {
+				Visibility string `json:"visibility"`
+			} `json:"displayExperience"`
+		} `json:"creationBehavior"`
+		TriggeringBehavior struct {
+			Operation          operation `json:"operation"`
+			NotificationConfig struct {
+				PlayAudible bool `json:"playAudible"`
+			} `json:"notificationConfig"`
+		} `json:"triggeringBehavior"`
+	}{Duration: ISODuration(t.Duration), TimerLabel: t.Label}
+	req.CreationBehavior.DisplayExperience.Visibility = "VISIBLE"
+	req.TriggeringBehavior.Operation = operation{Type: t.Operation, TextToAnnounce: t.Announcements}
+	req.TriggeringBehavior.NotificationConfig.PlayAudible = t.PlayAudible
+	return json.Marshal(req)
+}
+
+// validate checks the timer, returning an error wrapping ErrInvalidTimer.
+func (t *TimerRequest) validate() error {
+	if t == nil {
+		return invalidTimer("timer must be set")
+	}
+	if t.Duration < time.Second || t.Duration > maxTimerDuration {
+		return invalidTimer("duration must be between 1 second and 24 hours")
+	}
+	switch t.Operation {
+	case TimerOperationAnnounce:
+		if len(t.Announcements) == 0 {
+			return invalidTimer("announce timer requires text to announce")
+		}
+	case TimerOperationNotifyOnly:
+	default:
+		return invalidTimer("unknown operation " + t.Operation)
+	}
+	return nil
+}
+
+func invalidTimer(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTimer, reason)
+}
+
+// Timer describes a timer created by the skill.
+type Timer struct {
+	ID                      string      `json:"id"`
+	Status                  string      `json:"status"`
+	Duration                ISODuration `json:"duration"`
+	TriggerTime             string      `json:"triggerTime"`
+	TimerLabel              string      `json:"timerLabel"`
+	CreatedTime             string      `json:"createdTime"`
+	UpdatedTime             string      `json:"updatedTime"`
+	RemainingTimeWhenPaused ISODuration `json:"remainingTimeWhenPaused"`
+}
+
+// TimersClient creates and manages timers with the Timers API.
+type TimersClient struct {
+	client *serviceClient
+}
+
+// NewTimersClient creates a TimersClient using the API endpoint and access
+// token of the request Context.
+func NewTimersClient(c *Context) *TimersClient {
+	return NewServiceClientFactory(c).TimersClient()
+}
+
+// Create starts a timer. It returns an error wrapping ErrInvalidTimer if
+// timer is nil or not valid.
+func (t *TimersClient) Create(ctx context.Context, timer *TimerRequest) (*Timer, error) {
+	err := timer.validate()
+	if err != nil {
+		return nil, err
+	}
+	return t.timer(ctx, http.MethodPost, timersPath, timer)
+}
+
+// Get returns the timer with id.
+func (t *TimersClient) Get(ctx context.Context, id string) (*Timer, error) {
+	return t.timer(ctx, http.MethodGet, timerPath(id), nil)
+}
+
+// List returns the timers created by the skill.
+func (t *TimersClient) List(ctx context.Context) ([]Timer, error) {
+	var resp struct {
+		Timers []Timer `json:"timers"`
+	}
+	err := t.client.do(ctx, http.MethodGet, timersPath, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Timers, nil
+}
+
+// Pause pauses the timer with id.
+func (t *TimersClient) Pause(ctx context.Context, id string) error {
+	return t.client.do(ctx, http.MethodPost, timerPath(id)+"/pause", nil, nil)
+}
+
+// Resume resumes the paused timer with id.
+func (t *TimersClient) Resume(ctx context.Context, id string) error {
+	return t.client.do(ctx, http.MethodPost, timerPath(id)+"/resume", nil, nil)
+}
+
+// Delete deletes the timer with id.
+func (t *TimersClient) Delete(ctx context.Context, id string) error {
+	return t.client.do(ctx, http.MethodDelete, timerPath(id), nil, nil)
+}
+
+// DeleteAll deletes all timers created by the skill.
+func (t *TimersClient) DeleteAll(ctx context.Context) error {
+	return t.client.do(ctx, http.MethodDelete, timersPath, nil, nil)
+}
+
+func (t *TimersClient) timer(ctx context.Context, method, path string, in interface{}) (*Timer, error) {
+	timer := &Timer{}
+	err := t.client.do(ctx, method, path, in, timer)
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func timerPath(id string) string {
+	return timersPath + "/" + url.PathEscape(id)
+}
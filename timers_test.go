@@ -0,0 +1,182 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFormatISODuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                "PT0S",
+		10 * time.Minute: "PT10M",
+		90 * time.Second: "PT1M30S",
+		time.Hour + 30*time.Minute + 15*time.Second: "PT1H30M15S",
+		24 * time.Hour:          "PT24H",
+		1500 * time.Millisecond: "PT2S",
+		-5 * time.Minute:        "-PT5M",
+	}
+	for d, expected := range tests {
+		if s := FormatISODuration(d); s != expected {
+			t.Errorf("Expected %s for %s but was %s", expected, d, s)
+		}
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT0S":       0,
+		"PT10M":      10 * time.Minute,
+		"PT1H30M15S": time.Hour + 30*time.Minute + 15*time.Second,
+		"P1DT2H":     26 * time.Hour,
+		"P2D":        48 * time.Hour,
+		"PT10M30.5S": 10*time.Minute + 30500*time.Millisecond,
+		"-PT5M":      -5 * time.Minute,
+	}
+	for s, expected := range tests {
+		d, err := ParseISODuration(s)
+		if err != nil {
+			t.Errorf("Error parsing %s. %s", s, err.Error())
+		} else if d != expected {
+			t.Errorf("Expected %s for %s but was %s", expected, s, d)
+		}
+	}
+	invalid := []struct {
+		name string
+		s    string
+	}{
+		{"empty", ""},
+		{"no components", "P"},
+		{"no time components", "PT"},
+		{"no designator", "10M"},
+		{"no unit", "PT10"},
+		{"hours before T", "P1H"},
+		{"days after T", "PT1D"},
+		{"weeks", "P1W"},
+		{"not a number", "PTXM"},
+		{"trailing T", "P1DT"},
+		{"exponent", "PT1e3S"},
+		{"infinity", "PTInfS"},
+		{"not a number value", "PTNaNS"},
+		{"sign in component", "PT+5M"},
+		{"leading fraction", "PT.5S"},
+		{"trailing point", "PT5.S"},
+		{"repeated unit", "PT1M2M"},
+		{"out of order", "PT1S2M"},
+		{"repeated T", "PT1HT2M"},
+		{"overflow", "P999999999999D"},
+	}
+	for _, test := range invalid {
+		_, err := ParseISODuration(test.s)
+		if err == nil {
+			t.Errorf("Expected an error parsing %s %q", test.name, test.s)
+		}
+	}
+}
+
+func TestTimersClient(t *testing.T) {
+	var created map[string]interface{}
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-api-token" {
+			t.Errorf("Expected bearer token but was %s", auth)
+		}
+		call := r.Method + " " + r.URL.Path
+		calls = append(calls, call)
+		timer := `{"id":"timer-1","status":"ON","duration":"PT10M","timerLabel":"pasta","triggerTime":"2024-03-01T08:40:00Z","remainingTimeWhenPaused":"PT0S"}`
+		switch call {
+		case "POST /v1/alerts/timers":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(timer))
+		case "GET /v1/alerts/timers/timer-1":
+			w.Write([]byte(`{"id":"timer-1","status":"PAUSED","duration":"PT10M","remainingTimeWhenPaused":"PT4M30S"}`))
+		case "GET /v1/alerts/timers":
+			w.Write([]byte(`{"timers":[` + timer + `],"totalCount":1,"nextToken":null}`))
+		case "POST /v1/alerts/timers/timer-1/pause", "POST /v1/alerts/timers/timer-1/resume",
+			"DELETE /v1/alerts/timers/timer-1", "DELETE /v1/alerts/timers":
+		default:
+			t.Errorf("Unexpected request %s", call)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewTimersClient(newServiceContext(server.URL))
+
+	timer, err := client.Create(ctx, NewAnnounceTimer(10*time.Minute, "pasta", "en-US", "Your pasta is ready."))
+	if err != nil {
+		t.Fatal("Error creating timer. " + err.Error())
+	}
+	if timer.ID != "timer-1" || time.Duration(timer.Duration) != 10*time.Minute || timer.TimerLabel != "pasta" {
+		t.Errorf("Unexpected timer %+v", timer)
+	}
+	b, _ := json.Marshal(created)
+	expected := `{"creationBehavior":{"displayExperience":{"visibility":"VISIBLE"}},"duration":"PT10M","timerLabel":"pasta","triggeringBehavior":{"notificationConfig":{"playAudible":true},"operation":{"textToAnnounce":[{"locale":"en-US","text":"Your pasta is ready."}],"type":"ANNOUNCE"}}}`
+	if string(b) != expected {
+		t.Errorf("Expected %s but was %s", expected, b)
+	}
+
+	timer, err = client.Get(ctx, "timer-1")
+	if err != nil {
+		t.Fatal("Error getting timer. " + err.Error())
+	}
+	if timer.Status != TimerStatusPaused || time.Duration(timer.RemainingTimeWhenPaused) != 270*time.Second {
+		t.Errorf("Unexpected timer %+v", timer)
+	}
+
+	timers, err := client.List(ctx)
+	if err != nil {
+		t.Fatal("Error listing timers. " + err.Error())
+	}
+	if len(timers) != 1 || timers[0].Status != TimerStatusOn {
+		t.Errorf("Unexpected timers %+v", timers)
+	}
+
+	for _, f := range []func(context.Context, string) error{client.Pause, client.Resume, client.Delete} {
+		err = f(ctx, "timer-1")
+		if err != nil {
+			t.Fatal("Error updating timer. " + err.Error())
+		}
+	}
+	err = client.DeleteAll(ctx)
+	if err != nil {
+		t.Fatal("Error deleting timers. " + err.Error())
+	}
+	if len(calls) != 7 {
+		t.Errorf("Expected 7 calls but was %v", calls)
+	}
+}
+
+func TestTimersClientValidation(t *testing.T) {
+	client := NewTimersClient(newServiceContext("http://127.0.0.1:0"))
+	for name, timer := range map[string]*TimerRequest{
+		"too short":         NewNotifyOnlyTimer(0, "eggs"),
+		"too long":          NewNotifyOnlyTimer(25*time.Hour, "eggs"),
+		"no announcement":   {Duration: time.Minute, Operation: TimerOperationAnnounce},
+		"unknown operation": {Duration: time.Minute, Operation: "LAUNCH_ROCKET"},
+		"nil":               nil,
+	} {
+		_, err := client.Create(context.Background(), timer)
+		if !errors.Is(err, ErrInvalidTimer) {
+			t.Errorf("%s: expected ErrInvalidTimer but was %v", name, err)
+		}
+	}
+}
+
+func TestTimersClientPermissionNotGranted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code":"UNAUTHORIZED","message":"Request is not authorized."}`))
+	}))
+	defer server.Close()
+
+	_, err := NewTimersClient(newServiceContext(server.URL)).Create(context.Background(), NewNotifyOnlyTimer(time.Minute, "eggs"))
+	if !errors.Is(err, ErrPermissionNotGranted) {
+		t.Errorf("Expected ErrPermissionNotGranted but was %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package alexa
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const signatureCertChainURLHeader = "SignatureCertChainUrl"
+const signatureHeader = "Signature-256"
+const echoAPIDomain = "echo-api.amazon.com"
+const certURLHost = "s3.amazonaws.com"
+const certURLPathPrefix = "/echo.api/"
+
+// maxCertChainSize limits the size of a downloaded certificate chain.
+const maxCertChainSize = 64 * 1024
+
+// certFetchTimeout limits the time spent downloading a certificate chain.
+const certFetchTimeout = 10 * time.Second
+
+// maxRequestBodySize limits the size of a request body accepted by HTTPHandler.
+const maxRequestBodySize = 1024 * 1024
+
+// ErrMissingSignature reports that the request did not contain the signature headers.
+var ErrMissingSignature = errors.New("request is missing the " + signatureCertChainURLHeader + " or " + signatureHeader + " header")
+
+// ErrInvalidSignature reports that the request body did not match its signature.
+var ErrInvalidSignature = errors.New("request signature does not match the request body")
+
+// CertFetcher retrieves the PEM encoded signing certificate chain referenced by
+// the SignatureCertChainUrl header of an Alexa request.
+type CertFetcher interface {
+	FetchCertChain(ctx context.Context, certURL string) ([]byte, error)
+}
+
+// HTTPCertFetcher is the default CertFetcher, downloading the certificate
+// chain with the provided http.Client (or http.DefaultClient if nil).
+type HTTPCertFetcher struct {
+	Client *http.Client
+}
+
+// FetchCertChain downloads the certificate chain located at certURL.
+func (f *HTTPCertFetcher) FetchCertChain(ctx context.Context, certURL string) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download certificate chain, status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxCertChainSize))
+}
+
+// RequestVerifier verifies that a request was sent by Alexa by validating the
+// signing certificate chain and the signature of the request body. See
+// https://developer.amazon.com/en-US/docs/alexa/custom-skills/host-a-custom-skill-as-a-web-service.html
+type RequestVerifier struct {
+	// CertFetcher retrieves the signing certificate chain. Defaults to an HTTPCertFetcher.
+	CertFetcher CertFetcher
+	// Roots are the trusted root certificates. Defaults to the system roots.
+	Roots *x509.CertPool
+	// Cache holds validated signing certificates. Defaults to an LRUCertificateCache.
+	Cache CertificateCache
+	// Clock provides the current time for certificate validation. Defaults to the system clock.
+	Clock Clock
+
+	cacheOnce sync.Once
+	fetches   certFetchGroup
+}
+
+// VerifyHTTPRequest verifies the signature headers of r against the raw request body.
+func (v *RequestVerifier) VerifyHTTPRequest(r *http.Request, body []byte) error {
+	return v.Verify(r.Context(), r.Header.Get(signatureCertChainURLHeader), r.Header.Get(signatureHeader), body)
+}
+
+// Verify validates certURL, downloads and validates the certificate chain it
+// references and checks that signature is a valid signature of body.
+func (v *RequestVerifier) Verify(ctx context.Context, certURL string, signature string, body []byte) error {
+	if certURL == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	err := VerifyCertURL(certURL)
+	if err != nil {
+		return err
+	}
+
+	cert, err := v.signingCert(ctx, certURL)
+	if err != nil {
+		return err
+	}
+
+	return verifySignature(cert, signature, body)
+}
+
+// Prewarm downloads, validates and caches the signing certificates located at
+// certURLs so that later requests do not wait for them to be fetched.
+func (v *RequestVerifier) Prewarm(ctx context.Context, certURLs ...string) error {
+	for _, certURL := range certURLs {
+		err := VerifyCertURL(certURL)
+		if err != nil {
+			return err
+		}
+		_, err = v.signingCert(ctx, certURL)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cache returns the CertificateCache, creating the default cache if none was set.
+func (v *RequestVerifier) cache() CertificateCache {
+	v.cacheOnce.Do(func() {
+		if v.Cache == nil {
+			v.Cache = NewLRUCertificateCache(defaultCertCacheSize)
+		}
+	})
+	return v.Cache
+}
+
+// signingCert returns the cached signing certificate for certURL, fetching it
+// if it is not cached or has expired. Concurrent fetches for the same
+// certURL are combined into one, which runs detached from the cancellation of
+// ctx so that one cancelled request does not fail the others waiting for it.
+// A waiting request returns early if its own ctx is done.
+func (v *RequestVerifier) signingCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	cache := v.cache()
+	now := v.now()
+	if cert, ok := cache.Get(certURL); ok {
+		if now.Before(cert.NotAfter) {
+			return cert, nil
+		}
+		cache.Remove(certURL)
+	}
+
+	return v.fetches.do(ctx, certURL, func() (*x509.Certificate, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), certFetchTimeout)
+		defer cancel()
+		cert, err := v.fetchCert(fetchCtx, certURL)
+		if err != nil {
+			return nil, err
+		}
+		cache.Put(certURL, cert)
+		return cert, nil
+	})
+}
+
+// fetchCert downloads and validates the signing certificate chain.
+func (v *RequestVerifier) fetchCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	fetcher := v.CertFetcher
+	if fetcher == nil {
+		fetcher = &HTTPCertFetcher{}
+	}
+
+	data, err := fetcher.FetchCertChain(ctx, certURL)
+	if err != nil {
+		return nil, errors.New("unable to fetch signing certificate chain.  Err: " + err.Error())
+	}
+
+	return v.parseCertChain(data, v.now())
+}
+
+// now returns the current time from the Clock.
+func (v *RequestVerifier) now() time.Time {
+	if v.Clock == nil {
+		return systemClock{}.Now()
+	}
+	return v.Clock.Now()
+}
+
+// parseCertChain parses a PEM encoded certificate chain and validates the
+// signing certificate against the trusted roots at the time now.
+func (v *RequestVerifier) parseCertChain(data []byte, now time.Time) (*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.New("unable to parse signing certificate.  Err: " + err.Error())
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("signing certificate chain contained no certificates")
+	}
+
+	leaf := certs[0]
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return nil, errors.New("signing certificate is not valid at " + now.String())
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       echoAPIDomain,
+		Roots:         v.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	if err != nil {
+		return nil, errors.New("invalid signing certificate chain.  Err: " + err.Error())
+	}
+
+	return leaf, nil
+}
+
+// verifySignature checks the base64 encoded SHA-256 signature of body against cert.
+func verifySignature(cert *x509.Certificate, signature string, body []byte) error {
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("signing certificate does not contain an RSA public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.New("unable to decode request signature.  Err: " + err.Error())
+	}
+
+	hash := sha256.Sum256(body)
+	if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig) != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// VerifyCertURL checks that certURL is a valid Alexa signing certificate
+// location: https, host s3.amazonaws.com, path under /echo.api/ and port 443
+// if specified.
+func VerifyCertURL(certURL string) error {
+	u, err := url.Parse(certURL)
+	if err != nil {
+		return errors.New("unable to parse certificate URL.  Err: " + err.Error())
+	}
+	if !strings.EqualFold(u.Scheme, "https") {
+		return errors.New("certificate URL scheme must be https but was " + u.Scheme)
+	}
+	if !strings.EqualFold(u.Hostname(), certURLHost) {
+		return errors.New("certificate URL host must be " + certURLHost + " but was " + u.Hostname())
+	}
+	if u.Port() != "" && u.Port() != "443" {
+		return errors.New("certificate URL port must be 443 but was " + u.Port())
+	}
+	if !strings.HasPrefix(path.Clean(u.Path), certURLPathPrefix) {
+		return errors.New("certificate URL path must start with " + certURLPathPrefix + " but was " + u.Path)
+	}
+
+	return nil
+}
+
+// HTTPHandler is an http.Handler that verifies requests sent by Alexa and
+// passes them to Alexa.ProcessRequest.
+type HTTPHandler struct {
+	Alexa *Alexa
+	// Verifier verifies request signatures. Defaults to a RequestVerifier
+	// created on first use and shared by all requests.
+	Verifier        *RequestVerifier
+	IgnoreSignature bool
+
+	verifierOnce sync.Once
+}
+
+// NewHTTPHandler creates an HTTPHandler for alexa using the default RequestVerifier.
+func NewHTTPHandler(alexa *Alexa) *HTTPHandler {
+	return &HTTPHandler{Alexa: alexa, Verifier: &RequestVerifier{}}
+}
+
+// ServeHTTP verifies and processes a request sent by Alexa.
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	if !h.IgnoreSignature {
+		err = h.verifier().VerifyHTTPRequest(r, body)
+		if err != nil {
+			h.Alexa.logger().Warn("Error verifying request signature.", "err", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	requestEnv := &RequestEnvelope{}
+	err = json.Unmarshal(body, requestEnv)
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	responseEnv, err := h.Alexa.ProcessRequest(r.Context(), requestEnv)
+	if err != nil {
+		h.Alexa.logger().Error("Error processing request.", "err", err)
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	b, err := json.Marshal(responseEnv)
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Write(b)
+}
+
+// verifier returns the Verifier, creating the default RequestVerifier once so
+// that its certificate cache is shared by all requests.
+func (h *HTTPHandler) verifier() *RequestVerifier {
+	h.verifierOnce.Do(func() {
+		if h.Verifier == nil {
+			h.Verifier = &RequestVerifier{}
+		}
+	})
+	return h.Verifier
+}
@@ -0,0 +1,228 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testCertURL = "https://s3.amazonaws.com/echo.api/echo-api-cert.pem"
+
+func TestVerifyCertURL(t *testing.T) {
+	valid := []string{
+		"https://s3.amazonaws.com/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com:443/echo.api/echo-api-cert.pem",
+		"HTTPS://S3.AMAZONAWS.COM/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/echo.api/../echo.api/echo-api-cert.pem",
+	}
+	for _, u := range valid {
+		if err := VerifyCertURL(u); err != nil {
+			t.Errorf("Expected %s to be valid but got error %s", u, err)
+		}
+	}
+
+	invalid := []string{
+		"http://s3.amazonaws.com/echo.api/echo-api-cert.pem",
+		"https://notamazon.com/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/EcHo.aPi/echo-api-cert.pem",
+		"https://s3.amazonaws.com/invalid.path/echo-api-cert.pem",
+		"https://s3.amazonaws.com:563/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/echo.api/../invalid.path/echo-api-cert.pem",
+	}
+	for _, u := range invalid {
+		if err := VerifyCertURL(u); err == nil {
+			t.Errorf("Expected %s to be invalid but no err was returned.", u)
+		}
+	}
+}
+
+func TestRequestVerifier(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, key := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	verifier := &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: leafPEM}, Roots: ca.pool()}
+	body := []byte(`{"version":"1.0"}`)
+	ctx := context.Background()
+
+	err := verifier.Verify(ctx, testCertURL, signBody(t, key, body), body)
+	if err != nil {
+		t.Error("Expected Verify to succeed but got error", err)
+	}
+
+	err = verifier.Verify(ctx, testCertURL, signBody(t, key, body), []byte(`{"version":"2.0"}`))
+	if err != ErrInvalidSignature {
+		t.Error("Expected Verify to fail with ErrInvalidSignature but got", err)
+	}
+
+	err = verifier.Verify(ctx, testCertURL, "", body)
+	if err != ErrMissingSignature {
+		t.Error("Expected Verify to fail with ErrMissingSignature but got", err)
+	}
+
+	err = verifier.Verify(ctx, "https://example.com/echo.api/cert.pem", signBody(t, key, body), body)
+	if err == nil {
+		t.Error("Expected Verify to fail due to an invalid certificate URL but no err was returned.")
+	}
+
+	expiredPEM, expiredKey := ca.issue(t, echoAPIDomain, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	verifier = &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: expiredPEM}, Roots: ca.pool()}
+	err = verifier.Verify(ctx, testCertURL, signBody(t, expiredKey, body), body)
+	if err == nil {
+		t.Error("Expected Verify to fail due to an expired certificate but no err was returned.")
+	}
+
+	verifier = &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: leafPEM}, Roots: ca.pool(), Clock: fixedClock(time.Now().Add(2 * time.Hour))}
+	err = verifier.Verify(ctx, testCertURL, signBody(t, key, body), body)
+	if err == nil {
+		t.Error("Expected Verify to fail due to a certificate expired at the Clock time but no err was returned.")
+	}
+
+	wrongNamePEM, wrongNameKey := ca.issue(t, "example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	verifier = &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: wrongNamePEM}, Roots: ca.pool()}
+	err = verifier.Verify(ctx, testCertURL, signBody(t, wrongNameKey, body), body)
+	if err == nil {
+		t.Error("Expected Verify to fail due to an invalid subject alternative name but no err was returned.")
+	}
+
+	verifier = &RequestVerifier{CertFetcher: staticCertFetcher{testCertURL: leafPEM}, Roots: newTestCA(t).pool()}
+	err = verifier.Verify(ctx, testCertURL, signBody(t, key, body), body)
+	if err == nil {
+		t.Error("Expected Verify to fail due to an untrusted root but no err was returned.")
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	ca := newTestCA(t)
+	leafPEM, key := ca.issue(t, echoAPIDomain, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	handler := NewHTTPHandler(getAlexaWithHandler(&simpleResponseHandler{}))
+	handler.Verifier.CertFetcher = staticCertFetcher{testCertURL: leafPEM}
+	handler.Verifier.Roots = ca.pool()
+
+	body, err := json.Marshal(createRecipeRequest())
+	if err != nil {
+		t.Fatal("Error marshaling request.", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(signatureCertChainURLHeader, testCertURL)
+	req.Header.Set(signatureHeader, signBody(t, key, body))
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 but was %d", rec.Code)
+	}
+	var responseEnv ResponseEnvelope
+	err = json.Unmarshal(rec.Body.Bytes(), &responseEnv)
+	if err != nil {
+		t.Fatal("Error unmarshaling response.", err)
+	}
+	if responseEnv.Response.OutputSpeech.Text != "Response Text" {
+		t.Errorf("Response Text should have been %s but was %s", "Response Text", responseEnv.Response.OutputSpeech.Text)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(signatureCertChainURLHeader, testCertURL)
+	req.Header.Set(signatureHeader, signBody(t, key, []byte("tampered")))
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid signature but was %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for a GET request but was %d", rec.Code)
+	}
+}
+
+type staticCertFetcher map[string][]byte
+
+func (f staticCertFetcher) FetchCertChain(ctx context.Context, certURL string) ([]byte, error) {
+	data, ok := f[certURL]
+	if !ok {
+		return nil, errors.New("certificate not found")
+	}
+	return data, nil
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("Error generating CA key.", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("Error creating CA certificate.", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal("Error parsing CA certificate.", err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue creates a PEM encoded signing certificate for dnsName valid between notBefore and notAfter.
+func (ca *testCA) issue(t *testing.T, dnsName string, notBefore time.Time, notAfter time.Time) ([]byte, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("Error generating key.", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal("Error creating certificate.", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), key
+}
+
+func signBody(t *testing.T, key *rsa.PrivateKey, body []byte) string {
+	hash := sha256.Sum256(body)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal("Error signing body.", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}